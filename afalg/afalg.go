@@ -0,0 +1,90 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package afalg
+
+import (
+	"os"
+
+	"github.com/opencontainers/go-digest"
+)
+
+var (
+	// SHA256 is the AF_ALG implementation of SHA-256. It is only available if
+	// the kernel supports it.
+	SHA256 digest.CryptoHash = newCryptoHash("sha256", 32, 64)
+
+	// SHA512 is the AF_ALG implementation of SHA-512. It is only available if
+	// the kernel supports it.
+	SHA512 digest.CryptoHash = newCryptoHash("sha512", 64, 128)
+
+	hashes = map[digest.Algorithm]digest.CryptoHash{
+		digest.SHA256: SHA256,
+		digest.SHA512: SHA512,
+	}
+)
+
+// Register replaces the implementations of the SHA-256 and SHA-512 algorithms
+// of the digest package with the AF_ALG ones, for the whole process, where the
+// kernel supports them. It returns the algorithms replaced.
+//
+// The replacements differ from the implementations of the standard library:
+// their state cannot be marshaled, so Algorithm.ResumableDigester returns
+// ErrDigesterNotResumable, and they panic if the kernel refuses to create or
+// update a hash, for example when running out of file descriptors.
+func Register() []digest.Algorithm {
+	var replaced []digest.Algorithm
+	for _, alg := range []digest.Algorithm{digest.SHA256, digest.SHA512} {
+		if h := hashes[alg]; h.Available() && digest.ReplaceAlgorithm(alg, h) {
+			replaced = append(replaced, alg)
+		}
+	}
+	return replaced
+}
+
+// FromFile returns the digest and size of the file at path. See FromOSFile.
+func FromFile(alg digest.Algorithm, path string) (digest.Descriptor, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return digest.Descriptor{}, err
+	}
+	defer f.Close()
+
+	return FromOSFile(alg, f)
+}
+
+// FromOSFile returns the digest and size of f, like Algorithm.FromOSFile in
+// the digest package. Regular files are spliced into the kernel hash without
+// being copied into user space. If the algorithm has no AF_ALG implementation
+// or the kernel lacks support, Algorithm.FromOSFile is used instead.
+func FromOSFile(alg digest.Algorithm, f *os.File) (digest.Descriptor, error) {
+	h, ok := hashes[alg].(*cryptoHash)
+	if !ok || !h.Available() {
+		return alg.FromOSFile(f)
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		return digest.Descriptor{}, err
+	}
+	if !fi.Mode().IsRegular() {
+		return alg.FromOSFile(f)
+	}
+
+	desc, err := h.spliceFile(alg, f, fi)
+	if err == errSpliceUnsupported {
+		return alg.FromOSFile(f)
+	}
+	return desc, err
+}
@@ -0,0 +1,323 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux && !386
+
+package afalg
+
+import (
+	"errors"
+	"fmt"
+	"hash"
+	"io/fs"
+	"os"
+	"runtime"
+	"sync"
+	"syscall"
+	"unsafe"
+
+	"github.com/opencontainers/go-digest"
+)
+
+const (
+	afALG = 38 // AF_ALG, not defined by the syscall package
+
+	spliceFMove = 0x1 // SPLICE_F_MOVE
+	spliceFMore = 0x4 // SPLICE_F_MORE
+
+	// spliceSize is the size of the chunks spliced through the pipe, which
+	// holds 64 KiB by default.
+	spliceSize = 64 << 10
+)
+
+var errSpliceUnsupported = errors.New("splice into AF_ALG socket not supported")
+
+// sockaddrALG is struct sockaddr_alg from linux/if_alg.h.
+type sockaddrALG struct {
+	Family uint16
+	Type   [14]byte
+	Feat   uint32
+	Mask   uint32
+	Name   [64]byte
+}
+
+// cryptoHash is a digest.CryptoHash backed by an AF_ALG transform socket.
+// The socket is bound on first use and kept open for the life of the process;
+// each hash accepts its own operation socket from it.
+type cryptoHash struct {
+	name      string
+	size      int
+	blockSize int
+
+	once sync.Once
+	tfm  int // bound transform socket, or -1 if unsupported
+}
+
+func newCryptoHash(name string, size, blockSize int) *cryptoHash {
+	return &cryptoHash{
+		name:      name,
+		size:      size,
+		blockSize: blockSize,
+	}
+}
+
+// Available reports whether the kernel supports the hash through AF_ALG.
+func (h *cryptoHash) Available() bool {
+	h.once.Do(func() {
+		h.tfm = -1
+		if fd, err := bindHash(h.name); err == nil {
+			h.tfm = fd
+		}
+	})
+	return h.tfm >= 0
+}
+
+func (h *cryptoHash) Size() int {
+	return h.size
+}
+
+// New returns a new hash. It panics if the hash is not available or the
+// kernel refuses to create it.
+func (h *cryptoHash) New() hash.Hash {
+	if !h.Available() {
+		panic(fmt.Sprintf("afalg: %s not supported by the kernel", h.name))
+	}
+	op, err := accept(h.tfm)
+	if err != nil {
+		panic(fmt.Sprintf("afalg: creating %s hash: %v", h.name, err))
+	}
+
+	d := &algHash{h: h, op: op}
+	runtime.SetFinalizer(d, (*algHash).close)
+	return d
+}
+
+// algHash is a hash.Hash calculated by the kernel. Data is sent with MSG_MORE
+// so that the operation socket keeps its state; Sum reads the digest from a
+// copy of the socket, leaving the original untouched.
+type algHash struct {
+	h  *cryptoHash
+	op int
+}
+
+func (d *algHash) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		n, _, errno := syscall.Syscall6(syscall.SYS_SENDTO, uintptr(d.op),
+			uintptr(unsafe.Pointer(&p[0])), uintptr(len(p)), syscall.MSG_MORE, 0, 0)
+		if errno == syscall.EINTR {
+			continue
+		}
+		if errno != 0 {
+			return written, errno
+		}
+		written += int(n)
+		p = p[n:]
+	}
+	runtime.KeepAlive(d)
+	return written, nil
+}
+
+func (d *algHash) Sum(b []byte) []byte {
+	clone, err := accept(d.op)
+	if err != nil {
+		panic(fmt.Sprintf("afalg: copying %s hash: %v", d.h.name, err))
+	}
+	defer syscall.Close(clone)
+	runtime.KeepAlive(d)
+
+	sum := make([]byte, d.h.size)
+	if err := readFull(clone, sum); err != nil {
+		panic(fmt.Sprintf("afalg: reading %s digest: %v", d.h.name, err))
+	}
+	return append(b, sum...)
+}
+
+func (d *algHash) Reset() {
+	op, err := accept(d.h.tfm)
+	if err != nil {
+		panic(fmt.Sprintf("afalg: creating %s hash: %v", d.h.name, err))
+	}
+	syscall.Close(d.op)
+	d.op = op
+}
+
+func (d *algHash) Size() int {
+	return d.h.size
+}
+
+func (d *algHash) BlockSize() int {
+	return d.h.blockSize
+}
+
+func (d *algHash) close() {
+	syscall.Close(d.op)
+}
+
+// spliceFile digests the regular file f, described by fi, by splicing it into
+// an operation socket through a pipe.
+func (h *cryptoHash) spliceFile(alg digest.Algorithm, f *os.File, fi fs.FileInfo) (digest.Descriptor, error) {
+	op, err := accept(h.tfm)
+	if err != nil {
+		return digest.Descriptor{}, err
+	}
+	defer syscall.Close(op)
+
+	rc, err := f.SyscallConn()
+	if err != nil {
+		return digest.Descriptor{}, err
+	}
+
+	var (
+		size      = fi.Size()
+		off       int64
+		spliceErr error
+	)
+	if err := rc.Control(func(fd uintptr) {
+		off, spliceErr = spliceInto(op, int(fd), size)
+	}); err != nil {
+		return digest.Descriptor{}, err
+	}
+	if spliceErr != nil {
+		return digest.Descriptor{}, spliceErr
+	}
+
+	sum := make([]byte, h.size)
+	if err := readFull(op, sum); err != nil {
+		return digest.Descriptor{}, err
+	}
+
+	after, err := f.Stat()
+	if err != nil {
+		return digest.Descriptor{}, err
+	}
+	if off != size || after.Size() != size || !after.ModTime().Equal(fi.ModTime()) {
+		return digest.Descriptor{}, digest.ErrFileModified
+	}
+
+	return digest.Descriptor{
+		Digest: digest.NewDigestFromBytes(alg, sum),
+		Size:   size,
+	}, nil
+}
+
+// spliceToSocket splices n bytes from the read end of a pipe into an operation
+// socket. It is a variable so that tests can fake the kernel refusing it.
+var spliceToSocket = func(pipe, op, n int) (int64, error) {
+	m, err := syscall.Splice(pipe, nil, op, nil, n, spliceFMove|spliceFMore)
+	return int64(m), err // syscall.Splice returns int on some 32-bit platforms
+}
+
+// spliceInto splices size bytes of the file fd, from offset 0, into the
+// operation socket op through a pipe, and returns the offset reached in the
+// file. If the kernel refuses to splice before any data reached op, it
+// returns errSpliceUnsupported so that callers can fall back to reading.
+func spliceInto(op, fd int, size int64) (int64, error) {
+	var pipe [2]int
+	if err := syscall.Pipe2(pipe[:], syscall.O_CLOEXEC); err != nil {
+		return 0, err
+	}
+	defer syscall.Close(pipe[0])
+	defer syscall.Close(pipe[1])
+
+	var off, hashed int64 // hashed counts the bytes that reached op
+	for off < size {
+		chunk := size - off
+		if chunk > spliceSize {
+			chunk = spliceSize
+		}
+		spliced, err := syscall.Splice(fd, &off, pipe[1], nil, int(chunk), spliceFMove|spliceFMore)
+		if err == syscall.EINTR {
+			continue
+		}
+		if err != nil {
+			return off, spliceError(err, hashed)
+		}
+		if spliced == 0 {
+			return off, nil // truncated, detected by the caller
+		}
+		for n := int64(spliced); n > 0; {
+			m, err := spliceToSocket(pipe[0], op, int(n))
+			if err == syscall.EINTR {
+				continue
+			}
+			if err != nil {
+				return off, spliceError(err, hashed)
+			}
+			n -= m
+			hashed += m
+		}
+	}
+	return off, nil
+}
+
+// spliceError returns errSpliceUnsupported for errors of the kernel refusing
+// to splice, as long as no data was hashed yet, and err otherwise.
+func spliceError(err error, hashed int64) error {
+	if hashed == 0 && (err == syscall.EINVAL || err == syscall.ENOSYS || err == syscall.EOPNOTSUPP) {
+		return errSpliceUnsupported
+	}
+	return err
+}
+
+// bindHash returns a transform socket bound to the named kernel hash.
+func bindHash(name string) (int, error) {
+	fd, err := syscall.Socket(afALG, syscall.SOCK_SEQPACKET|syscall.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return -1, err
+	}
+
+	sa := sockaddrALG{Family: afALG}
+	copy(sa.Type[:], "hash")
+	copy(sa.Name[:], name)
+	_, _, errno := syscall.Syscall(syscall.SYS_BIND, uintptr(fd), uintptr(unsafe.Pointer(&sa)), unsafe.Sizeof(sa))
+	if errno != 0 {
+		syscall.Close(fd)
+		return -1, errno
+	}
+	return fd, nil
+}
+
+// accept returns a new operation socket from a transform socket, or a copy of
+// an operation socket, including its state. AF_ALG sockets have no address,
+// so syscall.Accept4 cannot be used.
+func accept(fd int) (int, error) {
+	for {
+		nfd, _, errno := syscall.Syscall6(syscall.SYS_ACCEPT4, uintptr(fd), 0, 0, syscall.SOCK_CLOEXEC, 0, 0)
+		if errno == syscall.EINTR {
+			continue
+		}
+		if errno != 0 {
+			return -1, errno
+		}
+		return int(nfd), nil
+	}
+}
+
+func readFull(fd int, p []byte) error {
+	for len(p) > 0 {
+		n, err := syscall.Read(fd, p)
+		if err == syscall.EINTR {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return syscall.EIO
+		}
+		p = p[n:]
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux && !386
+
+package afalg
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+// TestSpliceUnsupported fakes the kernel refusing to splice into the AF_ALG
+// socket, which must make FromOSFile fall back to reading as long as nothing
+// was hashed yet.
+func TestSpliceUnsupported(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(path, make([]byte, 3*spliceSize+7), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	defer func(orig func(pipe, op, n int) (int64, error)) {
+		spliceToSocket = orig
+	}(spliceToSocket)
+
+	for _, testcase := range []struct {
+		Name      string
+		Successes int // splices into the socket before the failure
+		Err       error
+	}{
+		{Name: "First", Err: errSpliceUnsupported},
+		{Name: "Later", Successes: 1, Err: syscall.EINVAL},
+	} {
+		t.Run(testcase.Name, func(t *testing.T) {
+			calls := 0
+			spliceToSocket = func(pipe, op, n int) (int64, error) {
+				calls++
+				if calls > testcase.Successes {
+					return 0, syscall.EINVAL
+				}
+				// drain the pipe, as the socket would
+				buf := make([]byte, n)
+				m, err := syscall.Read(pipe, buf)
+				return int64(m), err
+			}
+
+			_, err := spliceInto(-1, int(f.Fd()), 3*spliceSize+7)
+			if err != testcase.Err {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !linux || 386
+
+package afalg
+
+import (
+	"errors"
+	"hash"
+	"io/fs"
+	"os"
+
+	"github.com/opencontainers/go-digest"
+)
+
+var errSpliceUnsupported = errors.New("splice not supported")
+
+// cryptoHash is never available on this platform.
+type cryptoHash struct {
+	size int
+}
+
+func newCryptoHash(name string, size, blockSize int) *cryptoHash {
+	return &cryptoHash{size: size}
+}
+
+func (h *cryptoHash) Available() bool {
+	return false
+}
+
+func (h *cryptoHash) Size() int {
+	return h.size
+}
+
+func (h *cryptoHash) New() hash.Hash {
+	panic("afalg: AF_ALG is not supported on this platform")
+}
+
+func (h *cryptoHash) spliceFile(alg digest.Algorithm, f *os.File, fi fs.FileInfo) (digest.Descriptor, error) {
+	return digest.Descriptor{}, errSpliceUnsupported
+}
@@ -0,0 +1,135 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package afalg
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+)
+
+// requireAvailable skips the test if the kernel does not support h, unless
+// AFALG_REQUIRED is set so that CI runs the AF_ALG paths rather than skipping.
+func requireAvailable(t *testing.T, h digest.CryptoHash) {
+	t.Helper()
+	if h.Available() {
+		return
+	}
+	if os.Getenv("AFALG_REQUIRED") != "" {
+		t.Fatal("AF_ALG required but not supported by the kernel")
+	}
+	t.Skip("AF_ALG not supported by the kernel")
+}
+
+func TestHash(t *testing.T) {
+	p := make([]byte, 1<<20+7)
+	if _, err := rand.Read(p); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, testcase := range []struct {
+		Name     string
+		Hash     digest.CryptoHash
+		Expected crypto.Hash
+	}{
+		{Name: "SHA256", Hash: SHA256, Expected: crypto.SHA256},
+		{Name: "SHA512", Hash: SHA512, Expected: crypto.SHA512},
+	} {
+		t.Run(testcase.Name, func(t *testing.T) {
+			requireAvailable(t, testcase.Hash)
+			if testcase.Hash.Size() != testcase.Expected.Size() {
+				t.Fatalf("unexpected size: %d", testcase.Hash.Size())
+			}
+
+			h, expected := testcase.Hash.New(), testcase.Expected.New()
+			if !bytes.Equal(h.Sum(nil), expected.Sum(nil)) {
+				t.Fatal("unexpected digest of empty content")
+			}
+
+			h.Write(p[:1000])
+			expected.Write(p[:1000])
+			if !bytes.Equal(h.Sum(nil), expected.Sum(nil)) {
+				t.Fatal("unexpected digest of partial content")
+			}
+
+			// Sum must not change the state of the hash
+			h.Write(p[1000:])
+			expected.Write(p[1000:])
+			if !bytes.Equal(h.Sum(nil), expected.Sum(nil)) {
+				t.Fatal("unexpected digest of full content")
+			}
+
+			h.Reset()
+			expected.Reset()
+			h.Write(p[:3])
+			expected.Write(p[:3])
+			if !bytes.Equal(h.Sum([]byte("prefix")), expected.Sum([]byte("prefix"))) {
+				t.Fatal("unexpected digest after reset")
+			}
+		})
+	}
+}
+
+func TestFromFile(t *testing.T) {
+	for _, size := range []int{0, 1000, 1<<20 + 7} {
+		p := make([]byte, size)
+		if _, err := rand.Read(p); err != nil {
+			t.Fatal(err)
+		}
+		path := filepath.Join(t.TempDir(), "file")
+		if err := os.WriteFile(path, p, 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		// falls back to the digest package when AF_ALG is not supported
+		for _, alg := range []digest.Algorithm{digest.SHA256, digest.SHA512} {
+			if os.Getenv("AFALG_REQUIRED") != "" && !hashes[alg].Available() {
+				t.Fatalf("AF_ALG required but %v not supported by the kernel", alg)
+			}
+			desc, err := FromFile(alg, path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected := digest.Descriptor{Digest: alg.FromBytes(p), Size: int64(size)}
+			if desc != expected {
+				t.Fatalf("unexpected descriptor: %+v != %+v", desc, expected)
+			}
+		}
+	}
+}
+
+func TestRegister(t *testing.T) {
+	replaced := Register()
+	for _, alg := range replaced {
+		if !hashes[alg].Available() {
+			t.Fatalf("unexpected replacement of %v", alg)
+		}
+		if _, err := alg.ResumableDigester(); !errors.Is(err, digest.ErrDigesterNotResumable) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if len(replaced) == 0 && SHA256.Available() {
+		t.Fatal("expected SHA-256 to be replaced")
+	}
+	if d := digest.FromString("hello"); d != "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824" {
+		t.Fatalf("unexpected digest: %v", d)
+	}
+}
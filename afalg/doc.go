@@ -0,0 +1,29 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package afalg offloads hashing to the Linux kernel crypto API through
+// AF_ALG sockets, which can use crypto accelerators available to the kernel.
+//
+// FromFile and FromOSFile digest files by splicing them into the kernel hash,
+// without copying their content into user space. They fall back to the digest
+// package on other platforms, or when the kernel lacks support.
+//
+// Register replaces the implementations of the SHA-256 and SHA-512 algorithms
+// of the digest package with AF_ALG-backed ones, if the kernel supports them.
+// This affects the whole process, and is opt-in because the replacements
+// behave differently: Algorithm.ResumableDigester reports them as not
+// resumable, and hashing panics if the kernel refuses a system call, for
+// example when running out of file descriptors. Every write to an AF_ALG hash
+// is a system call, so this pays off for large writes only.
+package afalg
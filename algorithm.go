@@ -17,6 +17,7 @@ package digest
 
 import (
 	"crypto"
+	"encoding/hex"
 	// make sure crypto.SHA256 is registered
 	_ "crypto/sha256"
 	// make sure crypto.sha512 and crypto.SHA384 are registered
@@ -125,7 +126,11 @@ var (
 	// Note that /A-F/ disallowed.
 	anchoredEncodedRegexps = map[Algorithm]*regexp.Regexp{}
 
-	// algorithmsLock protects algorithms, and anchoredEncodedRegexps
+	// hashPools contains pools of hashes for each algorithm, reused by
+	// FromBytes and FromReader to avoid allocating a hash for every call.
+	hashPools = map[Algorithm]*sync.Pool{}
+
+	// algorithmsLock protects algorithms, anchoredEncodedRegexps and hashPools
 	algorithmsLock sync.RWMutex
 )
 
@@ -154,6 +159,34 @@ func RegisterAlgorithm(algorithm Algorithm, implementation CryptoHash) bool {
 	// we need to allow for alternative digest algorithms to be implemented and for the user to pass their own
 	// custom regexp.
 	anchoredEncodedRegexps[algorithm] = hexDigestRegex(implementation)
+	hashPools[algorithm] = &sync.Pool{
+		New: func() interface{} {
+			return implementation.New()
+		},
+	}
+	return true
+}
+
+// ReplaceAlgorithm replaces the implementation of an algorithm that is already
+// registered, for example with a hardware accelerated one. The replacement must
+// produce digests of the same size. If the algorithm is not registered or the
+// sizes differ, the return value is false, otherwise if the replacement was
+// successful the return value is true.
+func ReplaceAlgorithm(algorithm Algorithm, implementation CryptoHash) bool {
+	algorithmsLock.Lock()
+	defer algorithmsLock.Unlock()
+
+	previous, ok := algorithms[algorithm]
+	if !ok || previous.Size() != implementation.Size() {
+		return false
+	}
+
+	algorithms[algorithm] = implementation
+	hashPools[algorithm] = &sync.Pool{
+		New: func() interface{} {
+			return implementation.New()
+		},
+	}
 	return true
 }
 
@@ -220,6 +253,16 @@ func (a Algorithm) Digester() Digester {
 	}
 }
 
+// CountingDigester returns a new digester for the specified algorithm that
+// also counts the bytes written to it. Like Hash, it panics if the algorithm
+// is not available.
+func (a Algorithm) CountingDigester() CountingDigester {
+	return &countingDigester{
+		alg:  a,
+		hash: &countingHash{Hash: a.Hash()},
+	}
+}
+
 // Hash returns a new hash as used by the algorithm. If not available, the
 // method will panic. Check Algorithm.Available() before calling.
 func (a Algorithm) Hash() hash.Hash {
@@ -244,6 +287,37 @@ func (a Algorithm) Hash() hash.Hash {
 	return algorithms[a].New()
 }
 
+// getHash returns a hash for the algorithm from its pool. The hash must be
+// handed back with putHash once the caller is done with it, and must not be
+// retained or shared afterwards. Like Hash, it panics if the algorithm is not
+// available.
+//
+// Only functions that are done with the hash when they return use the pool,
+// such as FromBytes, FromReader and Descriptor.Verify. Verifiers returned by
+// Digest.Verifier and NewDescriptorVerifier, like digesters, are deliberately
+// left out: they hold their hash for as long as the caller keeps them and have
+// no method to release it, so returning it to the pool could not be made safe.
+func (a Algorithm) getHash() hash.Hash {
+	if !a.Available() {
+		return a.Hash() // panics with the same message as Hash
+	}
+
+	algorithmsLock.RLock()
+	pool := hashPools[a]
+	algorithmsLock.RUnlock()
+	return pool.Get().(hash.Hash)
+}
+
+// putHash resets h and returns it to the pool of the algorithm.
+func (a Algorithm) putHash(h hash.Hash) {
+	algorithmsLock.RLock()
+	pool := hashPools[a]
+	algorithmsLock.RUnlock()
+
+	h.Reset()
+	pool.Put(h)
+}
+
 // Encode encodes the raw bytes of a digest, typically from a hash.Hash, into
 // the encoded portion of the digest.
 func (a Algorithm) Encode(d []byte) string {
@@ -252,23 +326,27 @@ func (a Algorithm) Encode(d []byte) string {
 	//
 	// We support dynamic registration now, but we do not allow for the user to
 	// specify their own custom format. Hash functions may only use hex encoding.
-	return fmt.Sprintf("%x", d)
+	return hex.EncodeToString(d)
 }
 
 // FromReader returns the digest of the reader using the algorithm.
 func (a Algorithm) FromReader(rd io.Reader) (Digest, error) {
-	d := a.Digester()
-	if _, err := io.Copy(d.Hash(), rd); err != nil {
+	h := a.getHash()
+	defer a.putHash(h)
+
+	if _, err := io.Copy(h, rd); err != nil {
 		return "", err
 	}
 
-	return d.Digest(), nil
+	return NewDigest(a, h), nil
 }
 
 // FromBytes digests the input and returns a Digest.
 func (a Algorithm) FromBytes(p []byte) Digest {
-	d := a.Digester()
-	if _, err := d.Hash().Write(p); err != nil {
+	h := a.getHash()
+	defer a.putHash(h)
+
+	if _, err := h.Write(p); err != nil {
 		// Writes to a Hash should never fail. None of the existing
 		// hash implementations in the stdlib or hashes vendored
 		// here can return errors from Write. Having a panic in this
@@ -277,7 +355,7 @@ func (a Algorithm) FromBytes(p []byte) Digest {
 		panic("write to hash function returned error: " + err.Error())
 	}
 
-	return d.Digest()
+	return NewDigest(a, h)
 }
 
 // FromString digests the string input and returns a Digest.
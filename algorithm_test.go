@@ -22,7 +22,9 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"hash"
 	"strings"
+	"sync"
 	"testing"
 )
 
@@ -156,3 +158,104 @@ func TestGoodAlgorithmNameRegistration(t *testing.T) {
 	expectNoPanic("sha256-test")
 	expectNoPanic("sha256_384")
 }
+
+// TestFromBytesConcurrent ensures that pooled hashes are never shared between
+// concurrent calls.
+func TestFromBytesConcurrent(t *testing.T) {
+	inputs := make([][]byte, 64)
+	expected := make([]Digest, len(inputs))
+	for i := range inputs {
+		inputs[i] = bytes.Repeat([]byte{byte(i)}, i*1024)
+		h := SHA256.Hash()
+		h.Write(inputs[i])
+		expected[i] = NewDigest(SHA256, h)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(inputs))
+	for i := range inputs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				dgst, err := SHA256.FromReader(bytes.NewReader(inputs[i]))
+				if err != nil {
+					errs <- err
+					return
+				}
+				if dgst != expected[i] || SHA256.FromBytes(inputs[i]) != expected[i] {
+					errs <- fmt.Errorf("unexpected digest for input %d", i)
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Fatal(err)
+	}
+}
+
+func BenchmarkFromBytes(b *testing.B) {
+	p := []byte(`{"mediaType":"application/vnd.oci.image.config.v1+json"}`)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = FromBytes(p)
+	}
+}
+
+func BenchmarkFromBytesParallel(b *testing.B) {
+	p := []byte(`{"mediaType":"application/vnd.oci.image.config.v1+json"}`)
+
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = FromBytes(p)
+		}
+	})
+}
+
+func BenchmarkFromReader(b *testing.B) {
+	p := []byte(`{"mediaType":"application/vnd.oci.image.config.v1+json"}`)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = FromReader(bytes.NewReader(p))
+	}
+}
+
+func TestReplaceAlgorithm(t *testing.T) {
+	const alg = Algorithm("sha256-replaced")
+	if ReplaceAlgorithm(alg, crypto.SHA256) {
+		t.Fatal("expected replacing an algorithm that is not registered to fail")
+	}
+
+	RegisterAlgorithm(alg, crypto.SHA256)
+	if ReplaceAlgorithm(alg, crypto.SHA512) {
+		t.Fatal("expected replacing an algorithm with a different size to fail")
+	}
+
+	replacement := &countingCryptoHash{CryptoHash: crypto.SHA256}
+	if !ReplaceAlgorithm(alg, replacement) {
+		t.Fatal("expected replacement to succeed")
+	}
+	alg.FromString("hello")
+	alg.Digester()
+	if replacement.count != 2 {
+		t.Fatalf("expected replacement to be used, got %d hashes", replacement.count)
+	}
+}
+
+// countingCryptoHash counts the hashes created by a CryptoHash.
+type countingCryptoHash struct {
+	CryptoHash
+	count int
+}
+
+func (c *countingCryptoHash) New() hash.Hash {
+	c.count++
+	return c.CryptoHash.New()
+}
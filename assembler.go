@@ -0,0 +1,272 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"sync"
+)
+
+var (
+	// ErrAssemblyOverlap returned when a part overlaps content already written
+	// to an Assembler.
+	ErrAssemblyOverlap = errors.New("part overlaps content already written")
+
+	// ErrAssemblyOutOfRange returned when a part lies outside of the expected
+	// size of an Assembler.
+	ErrAssemblyOutOfRange = errors.New("part out of range")
+
+	// ErrAssemblyIncomplete returned when the digest of an Assembler is
+	// requested while content is missing.
+	ErrAssemblyIncomplete = errors.New("content incomplete")
+
+	// ErrAssemblyClosed returned when using an Assembler after Close.
+	ErrAssemblyClosed = errors.New("assembler closed")
+)
+
+// defaultMemoryLimit is the amount of content an Assembler or a
+// QuarantineReader buffers in memory when no memory limit is set.
+const defaultMemoryLimit = 16 << 20
+
+// AssemblerOptions configures an Assembler. The zero value is ready to use.
+type AssemblerOptions struct {
+	// Size is the expected size of the content. If positive, parts beyond it
+	// are rejected and the content is incomplete until it is reached.
+	Size int64
+
+	// MemoryLimit is the amount of out-of-order content buffered in memory.
+	// Parts that do not fit are spilled to a temporary file. If zero, 16 MiB
+	// are used.
+	MemoryLimit int64
+
+	// TempDir is the directory of the temporary file. If empty, the default
+	// directory for temporary files is used.
+	TempDir string
+}
+
+// Assembler calculates the digest of content written as parts in any order,
+// such as a multipart upload. The contiguous content from the start is hashed
+// as soon as it is available; parts written ahead of it are kept until it
+// catches up. Parts must not overlap.
+//
+// An Assembler is safe for concurrent use. Close must be called to release
+// the temporary file, if any.
+type Assembler struct {
+	alg  Algorithm
+	opts AssemblerOptions
+
+	mu     sync.Mutex
+	hash   hash.Hash
+	offset int64           // end of the content hashed so far
+	parts  []assemblerPart // pending parts, sorted by offset
+	memory int64           // size of the pending parts held in memory
+	spill  *os.File
+	end    int64 // end of the content in spill
+	err    error // sticky error from spill, or ErrAssemblyClosed
+}
+
+// assemblerPart is a part written ahead of the hashed content. It is held
+// either in data or at spillOffset in the spill file.
+type assemblerPart struct {
+	offset      int64
+	size        int64
+	data        []byte
+	spillOffset int64
+}
+
+// NewAssembler returns an Assembler digesting with alg. Like Algorithm.Hash,
+// it panics if alg is not available.
+func NewAssembler(alg Algorithm, opts AssemblerOptions) *Assembler {
+	if opts.MemoryLimit == 0 {
+		opts.MemoryLimit = defaultMemoryLimit
+	}
+	return &Assembler{
+		alg:  alg,
+		opts: opts,
+		hash: alg.Hash(),
+	}
+}
+
+// WriteAt writes the part p at offset off of the content. It implements
+// io.WriterAt, and p is not retained.
+func (a *Assembler) WriteAt(p []byte, off int64) (int, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.err != nil {
+		return 0, a.err
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	if off < 0 || off > math.MaxInt64-int64(len(p)) {
+		return 0, fmt.Errorf("%w: %d bytes at offset %d", ErrAssemblyOutOfRange, len(p), off)
+	}
+	end := off + int64(len(p))
+	if a.opts.Size > 0 && end > a.opts.Size {
+		return 0, fmt.Errorf("%w: [%d, %d) with size %d", ErrAssemblyOutOfRange, off, end, a.opts.Size)
+	}
+	if off < a.offset {
+		return 0, fmt.Errorf("%w: [%d, %d) overlaps hashed content [0, %d)", ErrAssemblyOverlap, off, end, a.offset)
+	}
+
+	i := sort.Search(len(a.parts), func(i int) bool {
+		return a.parts[i].offset >= off
+	})
+	if i > 0 {
+		if prev := a.parts[i-1]; prev.offset+prev.size > off {
+			return 0, fmt.Errorf("%w: [%d, %d) overlaps [%d, %d)", ErrAssemblyOverlap, off, end, prev.offset, prev.offset+prev.size)
+		}
+	}
+	if i < len(a.parts) {
+		if next := a.parts[i]; next.offset < end {
+			return 0, fmt.Errorf("%w: [%d, %d) overlaps [%d, %d)", ErrAssemblyOverlap, off, end, next.offset, next.offset+next.size)
+		}
+	}
+
+	if off == a.offset {
+		a.hash.Write(p)
+		a.offset = end
+		if err := a.drain(); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+
+	part, err := a.store(p, off)
+	if err != nil {
+		return 0, err
+	}
+	a.parts = append(a.parts, assemblerPart{})
+	copy(a.parts[i+1:], a.parts[i:])
+	a.parts[i] = part
+	return len(p), nil
+}
+
+// store keeps a copy of p, in memory if it fits or else in the spill file.
+func (a *Assembler) store(p []byte, off int64) (assemblerPart, error) {
+	part := assemblerPart{offset: off, size: int64(len(p))}
+	if a.memory+part.size <= a.opts.MemoryLimit {
+		part.data = append([]byte(nil), p...)
+		a.memory += part.size
+		return part, nil
+	}
+
+	if a.spill == nil {
+		f, err := os.CreateTemp(a.opts.TempDir, "digest-assembly-")
+		if err != nil {
+			return part, err
+		}
+		a.spill = f
+	}
+	if _, err := a.spill.WriteAt(p, a.end); err != nil {
+		a.err = err
+		return part, err
+	}
+	part.spillOffset = a.end
+	a.end += part.size
+	return part, nil
+}
+
+// drain hashes the pending parts that continue the hashed content.
+func (a *Assembler) drain() error {
+	for len(a.parts) > 0 && a.parts[0].offset == a.offset {
+		part := a.parts[0]
+		if part.data != nil {
+			a.hash.Write(part.data)
+			a.memory -= part.size
+		} else if _, err := io.Copy(a.hash, io.NewSectionReader(a.spill, part.spillOffset, part.size)); err != nil {
+			a.err = err
+			return err
+		}
+		a.offset += part.size
+		a.parts[0] = assemblerPart{}
+		a.parts = a.parts[1:]
+	}
+
+	if a.spill != nil && a.end > 0 && !a.spilled() {
+		// reuse the space of the spill file once it holds no parts
+		if err := a.spill.Truncate(0); err != nil {
+			a.err = err
+			return err
+		}
+		a.end = 0
+	}
+	return nil
+}
+
+// spilled reports whether any pending part is held in the spill file.
+func (a *Assembler) spilled() bool {
+	for _, part := range a.parts {
+		if part.data == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Size returns the size of the contiguous content written from the start.
+func (a *Assembler) Size() int64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.offset
+}
+
+// Digest returns the digest of the content. An error matching
+// ErrAssemblyIncomplete, reporting the first missing range, is returned if
+// parts are missing before the last part written or before the expected size.
+func (a *Assembler) Digest() (Digest, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.err != nil {
+		return "", a.err
+	}
+	if len(a.parts) > 0 {
+		return "", fmt.Errorf("%w: missing [%d, %d)", ErrAssemblyIncomplete, a.offset, a.parts[0].offset)
+	}
+	if a.opts.Size > 0 && a.offset < a.opts.Size {
+		return "", fmt.Errorf("%w: missing [%d, %d)", ErrAssemblyIncomplete, a.offset, a.opts.Size)
+	}
+	return NewDigest(a.alg, a.hash), nil
+}
+
+// Close releases the pending parts and removes the temporary file, if any.
+func (a *Assembler) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.err = ErrAssemblyClosed
+	a.parts = nil
+	a.memory = 0
+	if a.spill == nil {
+		return nil
+	}
+
+	f := a.spill
+	a.spill = nil
+	err := f.Close()
+	if rerr := os.Remove(f.Name()); err == nil {
+		err = rerr
+	}
+	return err
+}
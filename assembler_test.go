@@ -0,0 +1,160 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"crypto/rand"
+	"errors"
+	"math"
+	mathrand "math/rand"
+	"os"
+	"sync"
+	"testing"
+)
+
+func TestAssembler(t *testing.T) {
+	const partSize = 1000
+
+	p := make([]byte, 100*partSize+123)
+	if _, err := rand.Read(p); err != nil {
+		t.Fatal(err)
+	}
+	var offsets []int
+	for off := 0; off < len(p); off += partSize {
+		offsets = append(offsets, off)
+	}
+	mathrand.Shuffle(len(offsets), func(i, j int) {
+		offsets[i], offsets[j] = offsets[j], offsets[i]
+	})
+
+	for _, testcase := range []struct {
+		Name        string
+		MemoryLimit int64
+	}{
+		{Name: "Memory"},
+		{Name: "Spill", MemoryLimit: 10 * partSize},
+	} {
+		t.Run(testcase.Name, func(t *testing.T) {
+			dir := t.TempDir()
+			a := NewAssembler(SHA512, AssemblerOptions{
+				Size:        int64(len(p)),
+				MemoryLimit: testcase.MemoryLimit,
+				TempDir:     dir,
+			})
+
+			var wg sync.WaitGroup
+			errs := make(chan error, len(offsets))
+			for _, off := range offsets {
+				wg.Add(1)
+				go func(off int) {
+					defer wg.Done()
+					end := off + partSize
+					if end > len(p) {
+						end = len(p)
+					}
+					if _, err := a.WriteAt(p[off:end], int64(off)); err != nil {
+						errs <- err
+					}
+				}(off)
+			}
+			wg.Wait()
+			close(errs)
+			for err := range errs {
+				t.Fatal(err)
+			}
+
+			dgst, err := a.Digest()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if expected := SHA512.FromBytes(p); dgst != expected {
+				t.Fatalf("unexpected digest: %v != %v", dgst, expected)
+			}
+			if a.Size() != int64(len(p)) {
+				t.Fatalf("unexpected size: %d", a.Size())
+			}
+
+			if err := a.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if entries, err := os.ReadDir(dir); err != nil || len(entries) != 0 {
+				t.Fatalf("expected temporary files to be removed: %v %v", entries, err)
+			}
+		})
+	}
+}
+
+func TestAssemblerErrors(t *testing.T) {
+	a := NewAssembler(SHA256, AssemblerOptions{Size: 100, MemoryLimit: 10, TempDir: t.TempDir()})
+	defer a.Close()
+
+	write := func(off, size int) error {
+		_, err := a.WriteAt(make([]byte, size), int64(off))
+		return err
+	}
+
+	if err := write(0, 10); err != nil {
+		t.Fatal(err)
+	}
+	if err := write(50, 20); err != nil {
+		t.Fatal(err)
+	}
+	for _, testcase := range []struct {
+		Offset, Size int
+		Err          error
+	}{
+		{Offset: 5, Size: 10, Err: ErrAssemblyOverlap},
+		{Offset: 40, Size: 11, Err: ErrAssemblyOverlap},
+		{Offset: 69, Size: 2, Err: ErrAssemblyOverlap},
+		{Offset: 55, Size: 1, Err: ErrAssemblyOverlap},
+		{Offset: 95, Size: 10, Err: ErrAssemblyOutOfRange},
+		{Offset: -1, Size: 1, Err: ErrAssemblyOutOfRange},
+	} {
+		if err := write(testcase.Offset, testcase.Size); !errors.Is(err, testcase.Err) {
+			t.Fatalf("unexpected error writing [%d, %d): %v", testcase.Offset, testcase.Offset+testcase.Size, err)
+		}
+	}
+
+	if _, err := a.Digest(); !errors.Is(err, ErrAssemblyIncomplete) || err.Error() != "content incomplete: missing [10, 50)" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := write(10, 40); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.Digest(); !errors.Is(err, ErrAssemblyIncomplete) || err.Error() != "content incomplete: missing [70, 100)" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := write(70, 30); err != nil {
+		t.Fatal(err)
+	}
+	if dgst, err := a.Digest(); err != nil || dgst != SHA256.FromBytes(make([]byte, 100)) {
+		t.Fatalf("unexpected digest: %v %v", dgst, err)
+	}
+
+	a.Close()
+	if err := write(100, 1); !errors.Is(err, ErrAssemblyClosed) {
+		t.Fatalf("unexpected error after close: %v", err)
+	}
+
+	// the end of a part must not overflow when the size is unknown
+	a = NewAssembler(SHA256, AssemblerOptions{})
+	defer a.Close()
+	if _, err := a.WriteAt([]byte("ab"), math.MaxInt64-1); !errors.Is(err, ErrAssemblyOutOfRange) {
+		t.Fatalf("unexpected error writing past the maximum offset: %v", err)
+	}
+	if _, err := a.WriteAt([]byte("a"), math.MaxInt64-1); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"context"
+	"hash"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// batchSize is the number of inputs claimed at a time by a worker of
+// FromBytesBatch, to keep coordination cheap relative to hashing small inputs.
+const batchSize = 64
+
+// FromBytesBatch digests each of the inputs and returns their digests, in the
+// same order. The inputs are spread across up to GOMAXPROCS goroutines, each
+// reusing a single hash. Like Algorithm.Hash, it panics if the algorithm is
+// not available.
+func (a Algorithm) FromBytesBatch(inputs [][]byte) []Digest {
+	dgsts := make([]Digest, len(inputs))
+
+	workers := runtime.GOMAXPROCS(0)
+	if n := (len(inputs) + batchSize - 1) / batchSize; n < workers {
+		workers = n
+	}
+	if workers <= 1 {
+		h := a.getHash()
+		defer a.putHash(h)
+		digestBatch(a, h, inputs, dgsts)
+		return dgsts
+	}
+
+	var (
+		wg   sync.WaitGroup
+		next int64
+	)
+	for i := 0; i < workers; i++ {
+		h := a.getHash() // panics here, rather than in a worker
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer a.putHash(h)
+
+			for {
+				start := int(atomic.AddInt64(&next, batchSize)) - batchSize
+				if start >= len(inputs) {
+					return
+				}
+				end := start + batchSize
+				if end > len(inputs) {
+					end = len(inputs)
+				}
+				digestBatch(a, h, inputs[start:end], dgsts[start:end])
+			}
+		}()
+	}
+	wg.Wait()
+
+	return dgsts
+}
+
+// digestBatch writes the digest of each input to dgsts, resetting h in
+// between.
+func digestBatch(a Algorithm, h hash.Hash, inputs [][]byte, dgsts []Digest) {
+	for i, p := range inputs {
+		h.Write(p)
+		dgsts[i] = NewDigest(a, h)
+		h.Reset()
+	}
+}
+
+// FromBytesStream digests each input received from in on up to GOMAXPROCS
+// goroutines, and sends the digests to the returned channel in the same order
+// as the inputs. The channel is closed once in is closed and all digests have
+// been sent, or when ctx is done. Like Algorithm.Hash, it panics if the
+// algorithm is not available.
+func (a Algorithm) FromBytesStream(ctx context.Context, in <-chan []byte) <-chan Digest {
+	type job struct {
+		p      []byte
+		result chan<- Digest
+	}
+
+	var (
+		workers = runtime.GOMAXPROCS(0)
+		jobs    = make(chan job, workers)
+		pending = make(chan chan Digest, 2*workers) // in input order
+		out     = make(chan Digest, workers)
+	)
+
+	go func() {
+		defer close(jobs)
+		defer close(pending)
+
+		for {
+			var (
+				p  []byte
+				ok bool
+			)
+			select {
+			case p, ok = <-in:
+				if !ok {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+
+			result := make(chan Digest, 1)
+			select {
+			case pending <- result:
+			case <-ctx.Done():
+				return
+			}
+			jobs <- job{p: p, result: result}
+		}
+	}()
+
+	for i := 0; i < workers; i++ {
+		h := a.getHash() // panics here, rather than in a worker
+		go func() {
+			defer a.putHash(h)
+
+			for j := range jobs {
+				h.Write(j.p)
+				j.result <- NewDigest(a, h)
+				h.Reset()
+			}
+		}()
+	}
+
+	go func() {
+		defer close(out)
+
+		for result := range pending {
+			select {
+			case out <- <-result:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
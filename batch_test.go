@@ -0,0 +1,101 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func batchInputs(n int) [][]byte {
+	inputs := make([][]byte, n)
+	for i := range inputs {
+		inputs[i] = []byte(fmt.Sprintf(`{"id":%d,"name":"document-%d"}`, i, i))
+	}
+	return inputs
+}
+
+func TestFromBytesBatch(t *testing.T) {
+	for _, n := range []int{0, 1, batchSize, 10*batchSize + 1} {
+		inputs := batchInputs(n)
+		expected := make([]Digest, n)
+		for i, p := range inputs {
+			expected[i] = SHA512.FromBytes(p)
+		}
+
+		if dgsts := SHA512.FromBytesBatch(inputs); !reflect.DeepEqual(dgsts, expected) {
+			t.Fatalf("unexpected digests for %d inputs", n)
+		}
+	}
+}
+
+func TestFromBytesStream(t *testing.T) {
+	inputs := batchInputs(1000)
+
+	in := make(chan []byte)
+	go func() {
+		defer close(in)
+		for _, p := range inputs {
+			in <- p
+		}
+	}()
+
+	var i int
+	for dgst := range SHA256.FromBytesStream(context.Background(), in) {
+		if expected := SHA256.FromBytes(inputs[i]); dgst != expected {
+			t.Fatalf("unexpected digest for input %d: %v != %v", i, dgst, expected)
+		}
+		i++
+	}
+	if i != len(inputs) {
+		t.Fatalf("unexpected number of digests: %d", i)
+	}
+}
+
+func TestFromBytesStreamCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	in := make(chan []byte) // never closed
+	out := SHA256.FromBytesStream(ctx, in)
+	in <- []byte("hello")
+	if dgst := <-out; dgst != SHA256.FromString("hello") {
+		t.Fatalf("unexpected digest: %v", dgst)
+	}
+
+	cancel()
+	for range out {
+	}
+}
+
+func BenchmarkFromBytesBatch(b *testing.B) {
+	inputs := batchInputs(10000)
+
+	b.Run("Serial", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, p := range inputs {
+				_ = FromBytes(p)
+			}
+		}
+	})
+	b.Run("Batch", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = Canonical.FromBytesBatch(inputs)
+		}
+	})
+}
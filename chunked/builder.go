@@ -0,0 +1,107 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chunked
+
+import (
+	"errors"
+	"fmt"
+	"hash"
+
+	"github.com/opencontainers/go-digest"
+)
+
+// ErrChunkSizeInvalid is returned for chunk sizes that are negative or larger
+// than MaxChunkSize.
+var ErrChunkSizeInvalid = errors.New("invalid chunk size")
+
+// Builder builds the Index of content written to it in a single pass. Chunks
+// end every chunk size bytes, if set, and wherever Cut is called, so that
+// chunks may follow boundaries within the content, such as files in an
+// archive.
+type Builder struct {
+	alg       digest.Algorithm
+	chunkSize int64
+	hash      hash.Hash
+	n         int64 // size of the current chunk
+	offset    int64 // offset of the current chunk
+	chunks    []Chunk
+}
+
+// NewBuilder returns a Builder digesting chunks with alg. If chunkSize is
+// zero, chunks end where Cut is called, or once they reach MaxChunkSize.
+func NewBuilder(alg digest.Algorithm, chunkSize int64) (*Builder, error) {
+	if !alg.Available() {
+		return nil, fmt.Errorf("%w: %s", digest.ErrDigestUnsupported, alg)
+	}
+	if chunkSize < 0 || chunkSize > MaxChunkSize {
+		return nil, fmt.Errorf("%w: %d", ErrChunkSizeInvalid, chunkSize)
+	}
+	if chunkSize == 0 {
+		chunkSize = MaxChunkSize
+	}
+	return &Builder{
+		alg:       alg,
+		chunkSize: chunkSize,
+		hash:      alg.Hash(),
+	}, nil
+}
+
+// Write adds p to the content. It never returns an error.
+func (b *Builder) Write(p []byte) (int, error) {
+	written := len(p)
+	for len(p) > 0 {
+		chunk := p
+		if rest := b.chunkSize - b.n; int64(len(chunk)) > rest {
+			chunk = chunk[:rest]
+		}
+		b.hash.Write(chunk)
+		b.n += int64(len(chunk))
+		p = p[len(chunk):]
+
+		if b.n == b.chunkSize {
+			b.Cut()
+		}
+	}
+	return written, nil
+}
+
+// Cut ends the current chunk. It does nothing if the current chunk is empty.
+func (b *Builder) Cut() {
+	if b.n == 0 {
+		return
+	}
+	b.chunks = append(b.chunks, b.chunk())
+	b.offset += b.n
+	b.n = 0
+	b.hash.Reset()
+}
+
+func (b *Builder) chunk() Chunk {
+	return Chunk{
+		Offset: b.offset,
+		Size:   b.n,
+		Digest: digest.NewDigest(b.alg, b.hash),
+	}
+}
+
+// Index returns the index of the content written so far, ending the last
+// chunk at the end of the content. More content may be written afterwards.
+func (b *Builder) Index() *Index {
+	chunks := append([]Chunk(nil), b.chunks...)
+	if b.n > 0 {
+		chunks = append(chunks, b.chunk())
+	}
+	return &Index{Algorithm: b.alg, Chunks: chunks}
+}
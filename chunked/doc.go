@@ -0,0 +1,23 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package chunked verifies random reads of content against a chunk index, for
+// content that is fetched lazily by byte ranges.
+//
+// An Index splits content into chunks of fixed or variable size and lists the
+// digest of each chunk. The index is identified by its root digest, the digest
+// of its binary encoding, so trusting the root digest is enough to trust every
+// chunk. A Builder produces the index of content in a single streaming pass,
+// and a ReaderAt verifies every chunk touched by a read.
+package chunked
@@ -0,0 +1,201 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chunked
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/opencontainers/go-digest"
+)
+
+// ErrIndexInvalid is returned for indexes that are malformed, or whose
+// encoding cannot be decoded.
+var ErrIndexInvalid = errors.New("invalid chunk index")
+
+// indexVersion is the version of the binary encoding of an Index.
+const indexVersion = 1
+
+// MaxChunkSize is the maximum size of a chunk. ReaderAt reads whole chunks
+// into memory, so the limit bounds the memory used by reads, even with an
+// index from an untrusted source.
+const MaxChunkSize = 64 << 20
+
+// Chunk is a chunk of content.
+type Chunk struct {
+	// Offset is the offset of the chunk in the content.
+	Offset int64
+
+	// Size is the size of the chunk. It is positive, and at most
+	// MaxChunkSize.
+	Size int64
+
+	// Digest is the digest of the chunk.
+	Digest digest.Digest
+}
+
+// Index lists the chunks of content, in order. Chunks are contiguous, start
+// at offset zero and all use the same algorithm. Empty content has no chunks.
+type Index struct {
+	Algorithm digest.Algorithm
+	Chunks    []Chunk
+}
+
+// Size returns the size of the content.
+func (ix *Index) Size() int64 {
+	if len(ix.Chunks) == 0 {
+		return 0
+	}
+	last := ix.Chunks[len(ix.Chunks)-1]
+	return last.Offset + last.Size
+}
+
+// Validate checks that the algorithm is available and the chunks are
+// contiguous, non-empty, no larger than MaxChunkSize and have valid digests of
+// the algorithm.
+func (ix *Index) Validate() error {
+	if !ix.Algorithm.Available() {
+		return fmt.Errorf("%w: unsupported algorithm %q", ErrIndexInvalid, ix.Algorithm)
+	}
+
+	var offset int64
+	for i, c := range ix.Chunks {
+		if c.Size <= 0 || c.Size > MaxChunkSize {
+			return fmt.Errorf("%w: chunk %d has size %d, expected at most %d", ErrIndexInvalid, i, c.Size, MaxChunkSize)
+		}
+		if c.Offset != offset {
+			return fmt.Errorf("%w: chunk %d has range [%d, %d), expected offset %d", ErrIndexInvalid, i, c.Offset, c.Offset+c.Size, offset)
+		}
+		if err := c.Digest.Validate(); err != nil {
+			return fmt.Errorf("%w: chunk %d: %v", ErrIndexInvalid, i, err)
+		}
+		if c.Digest.Algorithm() != ix.Algorithm {
+			return fmt.Errorf("%w: chunk %d has digest %s, expected algorithm %s", ErrIndexInvalid, i, c.Digest, ix.Algorithm)
+		}
+		offset += c.Size
+	}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The encoding is stable:
+// the version byte 0x01, the uvarint length and bytes of the algorithm, the
+// uvarint number of chunks, then for each chunk its uvarint size and the raw
+// bytes of its digest, as decoded from hex. Offsets are implied by the sizes.
+func (ix *Index) MarshalBinary() ([]byte, error) {
+	if err := ix.Validate(); err != nil {
+		return nil, err
+	}
+
+	var (
+		buf bytes.Buffer
+		tmp [binary.MaxVarintLen64]byte
+	)
+	putUvarint := func(u uint64) {
+		buf.Write(tmp[:binary.PutUvarint(tmp[:], u)])
+	}
+
+	buf.WriteByte(indexVersion)
+	putUvarint(uint64(len(ix.Algorithm)))
+	buf.WriteString(string(ix.Algorithm))
+	putUvarint(uint64(len(ix.Chunks)))
+	for _, c := range ix.Chunks {
+		putUvarint(uint64(c.Size))
+		sum, err := hex.DecodeString(c.Digest.Encoded())
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(sum)
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. The decoded index is
+// validated.
+func (ix *Index) UnmarshalBinary(p []byte) error {
+	r := bytes.NewReader(p)
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w: %s at byte %d", ErrIndexInvalid, reason, len(p)-r.Len())
+	}
+
+	if version, err := r.ReadByte(); err != nil || version != indexVersion {
+		return invalid("unsupported version")
+	}
+	n, err := binary.ReadUvarint(r)
+	if err != nil || n > uint64(r.Len()) {
+		return invalid("truncated algorithm")
+	}
+	name := make([]byte, n)
+	io.ReadFull(r, name)
+	alg := digest.Algorithm(name)
+	if !alg.Available() {
+		return fmt.Errorf("%w: unsupported algorithm %q", ErrIndexInvalid, alg)
+	}
+
+	count, err := binary.ReadUvarint(r)
+	if err != nil || count > uint64(r.Len()) {
+		return invalid("truncated chunk count")
+	}
+	chunks := make([]Chunk, 0, count)
+	sum := make([]byte, alg.Size())
+	var offset int64
+	for i := uint64(0); i < count; i++ {
+		size, err := binary.ReadUvarint(r)
+		if err != nil || size == 0 || size > MaxChunkSize || size > uint64(1<<63-1-offset) {
+			return invalid("invalid chunk size")
+		}
+		if _, err := io.ReadFull(r, sum); err != nil {
+			return invalid("truncated chunk digest")
+		}
+		chunks = append(chunks, Chunk{
+			Offset: offset,
+			Size:   int64(size),
+			Digest: digest.NewDigestFromBytes(alg, sum),
+		})
+		offset += int64(size)
+	}
+	if r.Len() != 0 {
+		return invalid("trailing data")
+	}
+
+	*ix = Index{Algorithm: alg, Chunks: chunks}
+	return nil
+}
+
+// Root returns the digest of the binary encoding of the index, with the
+// algorithm of the index.
+func (ix *Index) Root() (digest.Digest, error) {
+	p, err := ix.MarshalBinary()
+	if err != nil {
+		return "", err
+	}
+	return ix.Algorithm.FromBytes(p), nil
+}
+
+// Find returns the position of the chunk containing offset, or -1 if offset is
+// outside of the content.
+func (ix *Index) Find(offset int64) int {
+	if offset < 0 || offset >= ix.Size() {
+		return -1
+	}
+	return sort.Search(len(ix.Chunks), func(i int) bool {
+		c := ix.Chunks[i]
+		return c.Offset+c.Size > offset
+	})
+}
@@ -0,0 +1,153 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chunked
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+)
+
+func TestBuilder(t *testing.T) {
+	p := []byte("0123456789abcdefghij")
+
+	b, err := NewBuilder(digest.SHA256, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.CopyBuffer(b, bytes.NewReader(p[:18]), make([]byte, 3)); err != nil {
+		t.Fatal(err)
+	}
+	b.Cut() // variable chunk at 18
+	b.Cut() // no empty chunks
+	b.Write(p[18:])
+
+	expected := &Index{
+		Algorithm: digest.SHA256,
+		Chunks: []Chunk{
+			{Offset: 0, Size: 8, Digest: digest.FromBytes(p[0:8])},
+			{Offset: 8, Size: 8, Digest: digest.FromBytes(p[8:16])},
+			{Offset: 16, Size: 2, Digest: digest.FromBytes(p[16:18])},
+			{Offset: 18, Size: 2, Digest: digest.FromBytes(p[18:20])},
+		},
+	}
+	ix := b.Index()
+	if !reflect.DeepEqual(ix, expected) {
+		t.Fatalf("unexpected index: %+v", ix)
+	}
+	if ix.Size() != int64(len(p)) {
+		t.Fatalf("unexpected size: %d", ix.Size())
+	}
+
+	for _, testcase := range []struct {
+		Offset int64
+		Chunk  int
+	}{
+		{Offset: -1, Chunk: -1},
+		{Offset: 0, Chunk: 0},
+		{Offset: 7, Chunk: 0},
+		{Offset: 8, Chunk: 1},
+		{Offset: 17, Chunk: 2},
+		{Offset: 19, Chunk: 3},
+		{Offset: 20, Chunk: -1},
+	} {
+		if i := ix.Find(testcase.Offset); i != testcase.Chunk {
+			t.Fatalf("unexpected chunk for offset %d: %d", testcase.Offset, i)
+		}
+	}
+
+	for _, chunkSize := range []int64{-1, MaxChunkSize + 1} {
+		if _, err := NewBuilder(digest.SHA256, chunkSize); !errors.Is(err, ErrChunkSizeInvalid) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func TestIndexEncoding(t *testing.T) {
+	b, _ := NewBuilder(digest.SHA512, 0)
+	b.Write([]byte("hello"))
+	b.Cut()
+	b.Write([]byte("world"))
+	ix := b.Index()
+
+	p, err := ix.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := append([]byte{1, 6, 's', 'h', 'a', '5', '1', '2', 2, 5}, decodeHex(t, ix.Chunks[0].Digest)...)
+	expected = append(append(expected, 5), decodeHex(t, ix.Chunks[1].Digest)...)
+	if !bytes.Equal(p, expected) {
+		t.Fatalf("unexpected encoding: %x", p)
+	}
+
+	root, err := ix.Root()
+	if err != nil || root != digest.SHA512.FromBytes(p) {
+		t.Fatalf("unexpected root: %v %v", root, err)
+	}
+
+	var decoded Index
+	if err := decoded.UnmarshalBinary(p); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(&decoded, ix) {
+		t.Fatalf("unexpected decoded index: %+v", decoded)
+	}
+
+	for _, invalid := range [][]byte{
+		nil,
+		{2},
+		p[:len(p)-1],
+		append(append([]byte(nil), p...), 0),
+		{1, 4, 'b', 'e', 'a', 'n', 0},
+		{1, 6, 's', 'h', 'a', '5', '1', '2', 1, 0},
+		// a chunk of 2^62 bytes
+		append([]byte{1, 6, 's', 'h', 'a', '5', '1', '2', 1, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x40}, make([]byte, 64)...),
+	} {
+		if err := decoded.UnmarshalBinary(invalid); !errors.Is(err, ErrIndexInvalid) {
+			t.Fatalf("unexpected error decoding %x: %v", invalid, err)
+		}
+	}
+}
+
+func TestIndexValidate(t *testing.T) {
+	d := digest.FromString("a")
+	for _, ix := range []*Index{
+		{Algorithm: "bean"},
+		{Algorithm: digest.SHA256, Chunks: []Chunk{{Offset: 1, Size: 1, Digest: d}}},
+		{Algorithm: digest.SHA256, Chunks: []Chunk{{Offset: 0, Size: 0, Digest: d}}},
+		{Algorithm: digest.SHA256, Chunks: []Chunk{{Offset: 0, Size: 1, Digest: digest.SHA512.FromString("a")}}},
+		{Algorithm: digest.SHA256, Chunks: []Chunk{{Offset: 0, Size: 1, Digest: "sha256:abc"}}},
+		{Algorithm: digest.SHA256, Chunks: []Chunk{{Offset: 0, Size: 1, Digest: "bogus"}}},
+		{Algorithm: digest.SHA256, Chunks: []Chunk{{Offset: 0, Size: MaxChunkSize + 1, Digest: d}}},
+	} {
+		if err := ix.Validate(); !errors.Is(err, ErrIndexInvalid) {
+			t.Fatalf("unexpected error validating %+v: %v", ix, err)
+		}
+	}
+}
+
+func decodeHex(t *testing.T, d digest.Digest) []byte {
+	t.Helper()
+	p, err := hex.DecodeString(d.Encoded())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
@@ -0,0 +1,111 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chunked
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/opencontainers/go-digest"
+)
+
+// ReaderAt is an io.ReaderAt that verifies content read from an underlying
+// io.ReaderAt against an Index. Every chunk touched by a read is read in full
+// and verified before any of it is returned; a mismatch is reported as an
+// error matching digest.ErrDigestMismatch, wrapping a *digest.MismatchError.
+// It is safe for concurrent use if the underlying io.ReaderAt is.
+type ReaderAt struct {
+	r  io.ReaderAt
+	ix *Index
+}
+
+// NewReaderAt returns a ReaderAt reading content from r, verified against the
+// index. An error is returned if the index is not valid.
+func NewReaderAt(r io.ReaderAt, ix *Index) (*ReaderAt, error) {
+	if err := ix.Validate(); err != nil {
+		return nil, err
+	}
+	return &ReaderAt{r: r, ix: ix}, nil
+}
+
+// Size returns the size of the content.
+func (ra *ReaderAt) Size() int64 {
+	return ra.ix.Size()
+}
+
+// ReadAt implements io.ReaderAt.
+func (ra *ReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errors.New("chunked: negative offset")
+	}
+	if off >= ra.Size() {
+		return 0, io.EOF
+	}
+
+	var buf []byte
+	n := 0
+	for i := ra.ix.Find(off); n < len(p) && i < len(ra.ix.Chunks); i++ {
+		c := ra.ix.Chunks[i]
+		pos := off + int64(n) - c.Offset // position of the read in the chunk
+
+		if pos == 0 && int64(len(p)-n) >= c.Size {
+			// the whole chunk fits, read it directly into p
+			if err := ra.readChunk(p[n:n+int(c.Size)], i); err != nil {
+				return n, err
+			}
+			n += int(c.Size)
+			continue
+		}
+
+		if int64(cap(buf)) < c.Size {
+			buf = make([]byte, c.Size)
+		}
+		if err := ra.readChunk(buf[:c.Size], i); err != nil {
+			return n, err
+		}
+		n += copy(p[n:], buf[pos:c.Size])
+	}
+
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// readChunk reads chunk i into p, which has the size of the chunk, and
+// verifies it.
+func (ra *ReaderAt) readChunk(p []byte, i int) error {
+	c := ra.ix.Chunks[i]
+	n, err := ra.r.ReadAt(p, c.Offset)
+	if n == len(p) {
+		err = nil // io.ReaderAt may return io.EOF along with the last bytes
+	} else if err == nil || err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return fmt.Errorf("chunk %d at offset %d: %w", i, c.Offset, err)
+	}
+
+	if actual := ra.ix.Algorithm.FromBytes(p); actual != c.Digest {
+		return fmt.Errorf("chunk %d at offset %d: %w", i, c.Offset, &digest.MismatchError{
+			ExpectedDigest: c.Digest,
+			ExpectedSize:   c.Size,
+			ActualDigest:   actual,
+			ActualSize:     c.Size,
+		})
+	}
+	return nil
+}
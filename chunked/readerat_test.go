@@ -0,0 +1,114 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chunked
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+)
+
+func TestReaderAt(t *testing.T) {
+	p := make([]byte, 10000)
+	if _, err := rand.Read(p); err != nil {
+		t.Fatal(err)
+	}
+	b, _ := NewBuilder(digest.SHA256, 1000)
+	b.Write(p[:4321])
+	b.Cut()
+	b.Write(p[4321:])
+	ix := b.Index()
+
+	ra, err := NewReaderAt(bytes.NewReader(p), ix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ra.Size() != int64(len(p)) {
+		t.Fatalf("unexpected size: %d", ra.Size())
+	}
+
+	for _, testcase := range []struct {
+		Offset int64
+		Size   int
+		Err    error
+	}{
+		{Offset: 0, Size: 1000},
+		{Offset: 0, Size: 10000},
+		{Offset: 999, Size: 2},
+		{Offset: 4000, Size: 1000},
+		{Offset: 4321, Size: 1},
+		{Offset: 123, Size: 5000},
+		{Offset: 9990, Size: 20, Err: io.EOF},
+		{Offset: 10000, Size: 1, Err: io.EOF},
+	} {
+		buf := make([]byte, testcase.Size)
+		n, err := ra.ReadAt(buf, testcase.Offset)
+		if err != testcase.Err {
+			t.Fatalf("unexpected error reading %d bytes at %d: %v", testcase.Size, testcase.Offset, err)
+		}
+		end := testcase.Offset + int64(testcase.Size)
+		if end > int64(len(p)) {
+			end = int64(len(p))
+		}
+		if expected := p[testcase.Offset:end]; !bytes.Equal(buf[:n], expected) {
+			t.Fatalf("unexpected content at %d", testcase.Offset)
+		}
+	}
+
+	// reading sequentially through io.SectionReader yields the content
+	content, err := io.ReadAll(io.NewSectionReader(ra, 0, ra.Size()))
+	if err != nil || !bytes.Equal(content, p) {
+		t.Fatalf("unexpected content: %v", err)
+	}
+}
+
+func TestReaderAtMismatch(t *testing.T) {
+	p := bytes.Repeat([]byte("chunk"), 1000)
+	b, _ := NewBuilder(digest.SHA256, 1000)
+	b.Write(p)
+	ix := b.Index()
+
+	tampered := append([]byte(nil), p...)
+	tampered[2500] ^= 0xff
+
+	ra, err := NewReaderAt(bytes.NewReader(tampered), ix)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// chunks other than the tampered one are still served
+	if _, err := ra.ReadAt(make([]byte, 1000), 1000); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := ra.ReadAt(make([]byte, 1000), 1500)
+	var mismatch *digest.MismatchError
+	if n != 500 || !errors.Is(err, digest.ErrDigestMismatch) || !errors.As(err, &mismatch) {
+		t.Fatalf("unexpected read: %d %v", n, err)
+	}
+	if mismatch.ExpectedDigest != ix.Chunks[2].Digest {
+		t.Fatalf("unexpected mismatch: %+v", mismatch)
+	}
+
+	// truncated content is reported as well
+	ra, _ = NewReaderAt(bytes.NewReader(p[:4500]), ix)
+	if _, err := ra.ReadAt(make([]byte, 10), 4400); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package composite
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrAlgorithmUnsupported is returned for algorithms without composite
+	// checksums.
+	ErrAlgorithmUnsupported = errors.New("unsupported composite checksum algorithm")
+
+	// ErrPartSizeInvalid is returned for part sizes that are not positive.
+	ErrPartSizeInvalid = errors.New("invalid part size")
+
+	// ErrChecksumFormat is returned for checksums that are not in the
+	// <base64>-<N> notation.
+	ErrChecksumFormat = errors.New("invalid checksum format")
+)
+
+// Algorithm identifies the checksum algorithm of a composite checksum, using
+// the names of the S3 API.
+type Algorithm string
+
+const (
+	SHA256 Algorithm = "SHA256"
+	SHA1   Algorithm = "SHA1"
+	CRC32  Algorithm = "CRC32"  // IEEE polynomial
+	CRC32C Algorithm = "CRC32C" // Castagnoli polynomial
+)
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// Available returns true if the algorithm is supported.
+func (a Algorithm) Available() bool {
+	switch a {
+	case SHA256, SHA1, CRC32, CRC32C:
+		return true
+	}
+	return false
+}
+
+// Hash returns a new hash of the algorithm. CRC checksums are big-endian. It
+// panics if the algorithm is not available.
+func (a Algorithm) Hash() hash.Hash {
+	switch a {
+	case SHA256:
+		return sha256.New()
+	case SHA1:
+		return sha1.New()
+	case CRC32:
+		return crc32.NewIEEE()
+	case CRC32C:
+		return crc32.New(castagnoli)
+	}
+	panic(fmt.Sprintf("%v: %q", ErrAlgorithmUnsupported, string(a)))
+}
+
+// Part is the checksum of a part of the content.
+type Part struct {
+	// Number is the number of the part, starting at 1.
+	Number int
+
+	// Size is the size of the part.
+	Size int64
+
+	// Sum is the binary checksum of the part.
+	Sum []byte
+}
+
+// String returns the base64 encoded checksum of the part, as reported for
+// the part by the object store.
+func (p Part) String() string {
+	return base64.StdEncoding.EncodeToString(p.Sum)
+}
+
+// Checksum is a composite checksum.
+type Checksum struct {
+	// Sum is the binary checksum of the concatenated part checksums.
+	Sum []byte
+
+	// Parts is the number of parts.
+	Parts int
+}
+
+// Combine returns the composite checksum of the parts, which must be in
+// order.
+func Combine(alg Algorithm, parts []Part) Checksum {
+	h := alg.Hash()
+	for _, part := range parts {
+		h.Write(part.Sum)
+	}
+	return Checksum{Sum: h.Sum(nil), Parts: len(parts)}
+}
+
+// ParseChecksum parses a checksum in the <base64>-<N> notation.
+func ParseChecksum(s string) (Checksum, error) {
+	i := strings.LastIndexByte(s, '-')
+	if i < 0 {
+		return Checksum{}, fmt.Errorf("%w: %q: missing number of parts", ErrChecksumFormat, s)
+	}
+
+	n, err := strconv.Atoi(s[i+1:])
+	if err != nil || n <= 0 || s[i+1] == '+' {
+		return Checksum{}, fmt.Errorf("%w: %q: invalid number of parts", ErrChecksumFormat, s)
+	}
+	sum, err := base64.StdEncoding.DecodeString(s[:i])
+	if err != nil || len(sum) == 0 {
+		return Checksum{}, fmt.Errorf("%w: %q: invalid base64", ErrChecksumFormat, s)
+	}
+	return Checksum{Sum: sum, Parts: n}, nil
+}
+
+// String returns the checksum in the <base64>-<N> notation.
+func (c Checksum) String() string {
+	return base64.StdEncoding.EncodeToString(c.Sum) + "-" + strconv.Itoa(c.Parts)
+}
+
+// Equal reports whether c and other have the same checksum and number of
+// parts.
+func (c Checksum) Equal(other Checksum) bool {
+	return c.Parts == other.Parts && bytes.Equal(c.Sum, other.Sum)
+}
+
+// Digester calculates the checksums of the parts of content written to it,
+// and their composite checksum.
+type Digester struct {
+	alg      Algorithm
+	partSize int64
+	hash     hash.Hash
+	n        int64 // size of the current part
+	parts    []Part
+}
+
+// NewDigester returns a Digester splitting content into parts of partSize
+// bytes, with a shorter last part.
+func NewDigester(alg Algorithm, partSize int64) (*Digester, error) {
+	if !alg.Available() {
+		return nil, fmt.Errorf("%w: %q", ErrAlgorithmUnsupported, string(alg))
+	}
+	if partSize <= 0 {
+		return nil, fmt.Errorf("%w: %d", ErrPartSizeInvalid, partSize)
+	}
+	return &Digester{
+		alg:      alg,
+		partSize: partSize,
+		hash:     alg.Hash(),
+	}, nil
+}
+
+// Write adds p to the content. It never returns an error.
+func (d *Digester) Write(p []byte) (int, error) {
+	written := len(p)
+	for len(p) > 0 {
+		chunk := p
+		if rest := d.partSize - d.n; int64(len(chunk)) > rest {
+			chunk = chunk[:rest]
+		}
+		d.hash.Write(chunk)
+		d.n += int64(len(chunk))
+		p = p[len(chunk):]
+
+		if d.n == d.partSize {
+			d.parts = append(d.parts, d.part())
+			d.hash.Reset()
+			d.n = 0
+		}
+	}
+	return written, nil
+}
+
+// part returns the current part.
+func (d *Digester) part() Part {
+	return Part{
+		Number: len(d.parts) + 1,
+		Size:   d.n,
+		Sum:    d.hash.Sum(nil),
+	}
+}
+
+// Parts returns the checksums of the parts of the content written so far,
+// including the last, shorter part. Empty content is a single empty part.
+func (d *Digester) Parts() []Part {
+	parts := append([]Part(nil), d.parts...)
+	if d.n > 0 || len(parts) == 0 {
+		parts = append(parts, d.part())
+	}
+	return parts
+}
+
+// Checksum returns the composite checksum of the content written so far.
+func (d *Digester) Checksum() Checksum {
+	return Combine(d.alg, d.Parts())
+}
+
+// Reset discards the content written so far.
+func (d *Digester) Reset() {
+	d.hash.Reset()
+	d.n = 0
+	d.parts = nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package composite
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestDigester(t *testing.T) {
+	p := bytes.Repeat([]byte("0123456789"), 2500) // 25000 bytes
+
+	d, err := NewDigester(SHA256, 10000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// write in chunks that do not align with the parts
+	if _, err := io.CopyBuffer(d, bytes.NewReader(p), make([]byte, 3333)); err != nil {
+		t.Fatal(err)
+	}
+
+	parts := d.Parts()
+	if len(parts) != 3 {
+		t.Fatalf("unexpected number of parts: %d", len(parts))
+	}
+	var sums []byte
+	for i, part := range parts {
+		end := (i + 1) * 10000
+		if end > len(p) {
+			end = len(p)
+		}
+		expected := sha256.Sum256(p[i*10000 : end])
+		if part.Number != i+1 || part.Size != int64(end-i*10000) || !bytes.Equal(part.Sum, expected[:]) {
+			t.Fatalf("unexpected part %d: %+v", i, part)
+		}
+		sums = append(sums, expected[:]...)
+	}
+
+	expected := sha256.Sum256(sums)
+	checksum := d.Checksum()
+	if !checksum.Equal(Checksum{Sum: expected[:], Parts: 3}) {
+		t.Fatalf("unexpected checksum: %v", checksum)
+	}
+
+	parsed, err := ParseChecksum(checksum.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !parsed.Equal(checksum) {
+		t.Fatalf("unexpected parsed checksum: %v != %v", parsed, checksum)
+	}
+
+	d.Reset()
+	if parts := d.Parts(); len(parts) != 1 || parts[0].Size != 0 {
+		t.Fatalf("expected a single empty part after reset: %+v", parts)
+	}
+}
+
+func TestDigesterCRC32C(t *testing.T) {
+	d, err := NewDigester(CRC32C, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.Write([]byte("123456789"))
+
+	var sums []string
+	for _, part := range d.Parts() {
+		sums = append(sums, hex.EncodeToString(part.Sum))
+	}
+	if strings.Join(sums, " ") != "18d12335 c27e5db2" {
+		t.Fatalf("unexpected part checksums: %v", sums)
+	}
+	if c := d.Checksum(); hex.EncodeToString(c.Sum) != "66b0ccaf" || c.String() != "ZrDMrw==-2" {
+		t.Fatalf("unexpected checksum: %v", c)
+	}
+}
+
+func TestParseChecksum(t *testing.T) {
+	for _, testcase := range []struct {
+		Input string
+		Err   error
+		Parts int
+	}{
+		{Input: "y9l/xQ==-2", Parts: 2},
+		{Input: "47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=-10000", Parts: 10000},
+		{Input: "y9l/xQ==", Err: ErrChecksumFormat},
+		{Input: "y9l/xQ==-0", Err: ErrChecksumFormat},
+		{Input: "y9l/xQ==--1", Err: ErrChecksumFormat},
+		{Input: "y9l/xQ==-+1", Err: ErrChecksumFormat},
+		{Input: "y9l/xQ==-x", Err: ErrChecksumFormat},
+		{Input: "y9l/xQ-1", Err: ErrChecksumFormat},
+		{Input: "-1", Err: ErrChecksumFormat},
+	} {
+		c, err := ParseChecksum(testcase.Input)
+		if !errors.Is(err, testcase.Err) {
+			t.Fatalf("unexpected error parsing %q: %v", testcase.Input, err)
+		}
+		if err != nil {
+			continue
+		}
+		if c.Parts != testcase.Parts || c.String() != testcase.Input {
+			t.Fatalf("unexpected checksum for %q: %v", testcase.Input, c)
+		}
+	}
+}
+
+func TestNewDigesterErrors(t *testing.T) {
+	if _, err := NewDigester("MD5", 1); !errors.Is(err, ErrAlgorithmUnsupported) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := NewDigester(CRC32, 0); !errors.Is(err, ErrPartSizeInvalid) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
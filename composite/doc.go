@@ -0,0 +1,27 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package composite calculates the composite checksums that object stores,
+// such as Amazon S3, report for multipart uploads.
+//
+// A composite checksum is the checksum of the concatenated binary checksums of
+// the parts, followed by the number of parts:
+//
+//	<base64 of checksum of part checksums>-<number of parts>
+//
+// Reproducing it requires the part size used by the upload. A Digester splits
+// content into parts of that size and provides both the checksum of every part
+// and the composite checksum, so that content can be checked against what the
+// object store reports.
+package composite
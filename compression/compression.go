@@ -0,0 +1,194 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compression
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/opencontainers/go-digest"
+)
+
+// Decompressor decompresses streams of a compression format.
+type Decompressor interface {
+	// NewReader returns a reader of the decompressed content of r.
+	NewReader(r io.Reader) (io.ReadCloser, error)
+}
+
+// DecompressorFunc adapts a function to the Decompressor interface.
+type DecompressorFunc func(r io.Reader) (io.ReadCloser, error)
+
+// NewReader calls f(r).
+func (f DecompressorFunc) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return f(r)
+}
+
+// Gzip decompresses gzip streams (RFC 1952), including concatenated ones.
+var Gzip Decompressor = DecompressorFunc(func(r io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(r)
+})
+
+// Options configures a Reader. The zero value is ready to use.
+type Options struct {
+	// Decompressor decompresses the stream. If nil, Gzip is used.
+	Decompressor Decompressor
+
+	// Compressed and Uncompressed are the expected digests of the compressed
+	// and uncompressed content. Either is optional, and only verified if set.
+	Compressed   digest.Digest
+	Uncompressed digest.Digest
+
+	// Algorithm calculates the digests that are not expected. If empty, the
+	// Canonical algorithm is used.
+	Algorithm digest.Algorithm
+}
+
+// Reader reads the decompressed content of a compressed stream, digesting the
+// compressed and uncompressed content. At the end of the stream, it returns an
+// error wrapping a *digest.MismatchError instead of io.EOF if either digest
+// does not match the expected one.
+type Reader struct {
+	compressed   digest.CountingDigester
+	uncompressed digest.CountingDigester
+	opts         Options
+
+	source io.Reader     // compressed stream, digested as it is read
+	dr     io.ReadCloser // decompressed stream
+	err    error         // sticky error, or io.EOF once verified
+}
+
+// NewReader returns a Reader decompressing r. An error is returned if an
+// expected digest is not valid, an algorithm is not available, or the
+// decompressor fails to start, for example on an invalid header.
+func NewReader(r io.Reader, opts Options) (*Reader, error) {
+	if opts.Decompressor == nil {
+		opts.Decompressor = Gzip
+	}
+	if opts.Algorithm == "" {
+		opts.Algorithm = digest.Canonical
+	}
+
+	if !opts.Algorithm.Available() {
+		return nil, fmt.Errorf("%w: %s", digest.ErrDigestUnsupported, opts.Algorithm)
+	}
+	calg, ualg := opts.Algorithm, opts.Algorithm
+	if opts.Compressed != "" {
+		if err := opts.Compressed.Validate(); err != nil {
+			return nil, fmt.Errorf("compressed digest: %w", err)
+		}
+		calg = opts.Compressed.Algorithm()
+	}
+	if opts.Uncompressed != "" {
+		if err := opts.Uncompressed.Validate(); err != nil {
+			return nil, fmt.Errorf("uncompressed digest: %w", err)
+		}
+		ualg = opts.Uncompressed.Algorithm()
+	}
+
+	cr := &Reader{
+		compressed:   calg.CountingDigester(),
+		uncompressed: ualg.CountingDigester(),
+		opts:         opts,
+	}
+	cr.source = io.TeeReader(r, cr.compressed.Hash())
+
+	dr, err := opts.Decompressor.NewReader(cr.source)
+	if err != nil {
+		return nil, err
+	}
+	cr.dr = dr
+	return cr, nil
+}
+
+// Read reads decompressed content.
+func (cr *Reader) Read(p []byte) (int, error) {
+	if cr.err != nil {
+		return 0, cr.err
+	}
+
+	n, err := cr.dr.Read(p)
+	cr.uncompressed.Hash().Write(p[:n])
+	if err == io.EOF {
+		err = cr.finish()
+	}
+	cr.err = err
+	return n, err
+}
+
+// finish digests any compressed content left after the end of the
+// decompressed content, and verifies the digests. It returns io.EOF if they
+// match.
+func (cr *Reader) finish() error {
+	if _, err := io.Copy(io.Discard, cr.source); err != nil {
+		return err
+	}
+	if err := verify("compressed", cr.opts.Compressed, cr.compressed); err != nil {
+		return err
+	}
+	if err := verify("uncompressed", cr.opts.Uncompressed, cr.uncompressed); err != nil {
+		return err
+	}
+	return io.EOF
+}
+
+func verify(what string, expected digest.Digest, d digest.CountingDigester) error {
+	if expected == "" {
+		return nil
+	}
+	if actual := d.Digest(); actual != expected {
+		return fmt.Errorf("%s content: %w", what, &digest.MismatchError{
+			ExpectedDigest: expected,
+			ExpectedSize:   d.Size(),
+			ActualDigest:   actual,
+			ActualSize:     d.Size(),
+		})
+	}
+	return nil
+}
+
+// Compressed returns the digest and size of the compressed content read so
+// far. Once Read returned io.EOF, it describes the whole stream.
+func (cr *Reader) Compressed() digest.Descriptor {
+	return cr.compressed.Descriptor()
+}
+
+// Uncompressed returns the digest and size of the uncompressed content read
+// so far.
+func (cr *Reader) Uncompressed() digest.Descriptor {
+	return cr.uncompressed.Descriptor()
+}
+
+// Close closes the decompressor. It does not close the compressed stream.
+func (cr *Reader) Close() error {
+	return cr.dr.Close()
+}
+
+// Verify reads the compressed stream r to the end, and returns the digests and
+// sizes of the compressed and uncompressed content. It returns an error if
+// the stream cannot be decompressed, or the digests do not match the expected
+// ones.
+func Verify(r io.Reader, opts Options) (compressed, uncompressed digest.Descriptor, err error) {
+	cr, err := NewReader(r, opts)
+	if err != nil {
+		return digest.Descriptor{}, digest.Descriptor{}, err
+	}
+	defer cr.Close()
+
+	if _, err := io.Copy(io.Discard, cr); err != nil {
+		return digest.Descriptor{}, digest.Descriptor{}, err
+	}
+	return cr.Compressed(), cr.Uncompressed(), nil
+}
@@ -0,0 +1,149 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compression
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+)
+
+func compress(t *testing.T, parts ...string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	for _, part := range parts {
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write([]byte(part)); err != nil {
+			t.Fatal(err)
+		}
+		if err := zw.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return buf.Bytes()
+}
+
+func TestReader(t *testing.T) {
+	content := strings.Repeat("layer content\n", 1000)
+	compressed := compress(t, content[:5000], content[5000:])
+	compressedDigest, uncompressedDigest := digest.FromBytes(compressed), digest.FromString(content)
+
+	for _, testcase := range []struct {
+		Name string
+		Opts Options
+		Err  string
+	}{
+		{Name: "Unverified"},
+		{Name: "Verified", Opts: Options{Compressed: compressedDigest, Uncompressed: uncompressedDigest}},
+		{Name: "CompressedOnly", Opts: Options{Compressed: compressedDigest}},
+		{Name: "UncompressedOnly", Opts: Options{Uncompressed: uncompressedDigest}},
+		{
+			Name: "CompressedMismatch",
+			Opts: Options{Compressed: uncompressedDigest, Uncompressed: uncompressedDigest},
+			Err:  "compressed content: content digest mismatch",
+		},
+		{
+			Name: "UncompressedMismatch",
+			Opts: Options{Compressed: compressedDigest, Uncompressed: compressedDigest},
+			Err:  "uncompressed content: content digest mismatch",
+		},
+	} {
+		t.Run(testcase.Name, func(t *testing.T) {
+			cr, err := NewReader(bytes.NewReader(compressed), testcase.Opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer cr.Close()
+
+			var buf bytes.Buffer
+			_, err = io.Copy(&buf, cr)
+			if testcase.Err != "" {
+				if !errors.Is(err, digest.ErrDigestMismatch) || !strings.HasPrefix(err.Error(), testcase.Err) {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if _, rerr := cr.Read(make([]byte, 1)); rerr != err {
+					t.Fatalf("expected error to be retained: %v", rerr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != content {
+				t.Fatal("unexpected content")
+			}
+
+			expectedCompressed := digest.Descriptor{Digest: compressedDigest, Size: int64(len(compressed))}
+			expectedUncompressed := digest.Descriptor{Digest: uncompressedDigest, Size: int64(len(content))}
+			if cr.Compressed() != expectedCompressed || cr.Uncompressed() != expectedUncompressed {
+				t.Fatalf("unexpected descriptors: %+v %+v", cr.Compressed(), cr.Uncompressed())
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	compressed := compress(t, "hello")
+
+	c, u, err := Verify(bytes.NewReader(compressed), Options{
+		Uncompressed: digest.SHA512.FromString("hello"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Digest != digest.FromBytes(compressed) || u.Digest != digest.SHA512.FromString("hello") || u.Size != 5 {
+		t.Fatalf("unexpected descriptors: %+v %+v", c, u)
+	}
+
+	if _, _, err := Verify(strings.NewReader("not a gzip stream"), Options{}); !errors.Is(err, gzip.ErrHeader) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, testcase := range []struct {
+		Opts Options
+		Err  error
+	}{
+		{Opts: Options{Algorithm: "bean"}, Err: digest.ErrDigestUnsupported},
+		{Opts: Options{Compressed: "bogus"}, Err: digest.ErrDigestInvalidFormat},
+		{Opts: Options{Uncompressed: "bean:0123"}, Err: digest.ErrDigestUnsupported},
+		{Opts: Options{Uncompressed: "sha256:0123"}, Err: digest.ErrDigestInvalidLength},
+	} {
+		if _, _, err := Verify(bytes.NewReader(compressed), testcase.Opts); !errors.Is(err, testcase.Err) {
+			t.Fatalf("unexpected error for %+v: %v", testcase.Opts, err)
+		}
+	}
+}
+
+func TestDecompressor(t *testing.T) {
+	// an identity decompressor, standing in for formats outside the stdlib
+	identity := DecompressorFunc(func(r io.Reader) (io.ReadCloser, error) {
+		return io.NopCloser(io.LimitReader(r, 3)), nil
+	})
+
+	c, u, err := Verify(strings.NewReader("abcdef"), Options{Decompressor: identity})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the compressed digest covers the whole stream, even if the decompressor
+	// stops reading early
+	if c.Digest != digest.FromString("abcdef") || u.Digest != digest.FromString("abc") {
+		t.Fatalf("unexpected descriptors: %+v %+v", c, u)
+	}
+}
@@ -0,0 +1,23 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package compression digests compressed content and its decompressed form in
+// a single pass, such as the digest and diff ID of an OCI image layer.
+//
+// A Reader decompresses a stream, calculating the digest of both the
+// compressed and the uncompressed content, and verifies them against expected
+// digests, if given, when reaching the end of the stream. Gzip is supported
+// out of the box, and other formats are supported by implementing
+// Decompressor.
+package compression
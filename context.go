@@ -0,0 +1,123 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// DefaultBufferSize is the buffer size used by FromReaderContext when
+// ReaderOptions.BufferSize is not set.
+const DefaultBufferSize = 32 << 10
+
+// Progress reports the progress of a digest calculation.
+type Progress struct {
+	// Bytes is the number of bytes digested so far.
+	Bytes int64
+
+	// Elapsed is the time since the digest calculation started.
+	Elapsed time.Duration
+}
+
+// Throughput returns the average number of bytes digested per second.
+func (p Progress) Throughput() float64 {
+	if p.Elapsed <= 0 {
+		return 0
+	}
+	return float64(p.Bytes) / p.Elapsed.Seconds()
+}
+
+// ReaderOptions configures FromReaderContext. The zero value is ready to use.
+type ReaderOptions struct {
+	// BufferSize is the size of the chunks read from the reader. If zero,
+	// DefaultBufferSize is used.
+	BufferSize int
+
+	// Progress, if set, is called after each chunk is digested.
+	Progress func(Progress)
+
+	// Pipeline reads the next chunk on a separate goroutine while the current
+	// one is digested, so that slow readers and hashing overlap. The result
+	// is identical to digesting without the pipeline. The overlap requires
+	// GOMAXPROCS to be at least 2.
+	Pipeline bool
+}
+
+// FromReaderContext consumes the content of rd until io.EOF, returning the
+// canonical digest. See Algorithm.FromReaderContext.
+func FromReaderContext(ctx context.Context, rd io.Reader, opts ReaderOptions) (Digest, error) {
+	return Canonical.FromReaderContext(ctx, rd, opts)
+}
+
+// FromReaderContext returns the digest of the reader using the algorithm,
+// like FromReader. The context is checked between chunks, and ctx.Err() is
+// returned as soon as the context is done. A Read that blocks is not
+// interrupted; readers that may block indefinitely should be closed when the
+// context is done.
+func (a Algorithm) FromReaderContext(ctx context.Context, rd io.Reader, opts ReaderOptions) (Digest, error) {
+	size := opts.BufferSize
+	if size <= 0 {
+		size = DefaultBufferSize
+	}
+
+	h := a.getHash()
+	defer a.putHash(h)
+
+	var (
+		start = time.Now()
+		total int64
+	)
+	write := func(p []byte) {
+		h.Write(p)
+		total += int64(len(p))
+		if opts.Progress != nil {
+			opts.Progress(Progress{Bytes: total, Elapsed: time.Since(start)})
+		}
+	}
+
+	copyFn := copyChunks
+	if opts.Pipeline {
+		copyFn = copyChunksPipelined
+	}
+	if err := copyFn(ctx, write, rd, size); err != nil {
+		return "", err
+	}
+
+	return NewDigest(a, h), nil
+}
+
+// copyChunks reads rd until io.EOF in chunks of up to size bytes, calling
+// write with each one. The context is checked before each read.
+func copyChunks(ctx context.Context, write func([]byte), rd io.Reader, size int) error {
+	buf := make([]byte, size)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		n, err := rd.Read(buf)
+		if n > 0 {
+			write(buf[:n])
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"testing"
+)
+
+func TestFromReaderContext(t *testing.T) {
+	p := make([]byte, 1<<20+1)
+	if _, err := rand.Read(p); err != nil {
+		t.Fatal(err)
+	}
+
+	var progress []Progress
+	dgst, err := FromReaderContext(context.Background(), bytes.NewReader(p), ReaderOptions{
+		BufferSize: 64 << 10,
+		Progress: func(p Progress) {
+			progress = append(progress, p)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dgst != FromBytes(p) {
+		t.Fatalf("unexpected digest: %v != %v", dgst, FromBytes(p))
+	}
+
+	if len(progress) != 17 {
+		t.Fatalf("unexpected number of progress reports: %d", len(progress))
+	}
+	for i, report := range progress[:16] {
+		if report.Bytes != int64(i+1)*64<<10 {
+			t.Fatalf("unexpected progress report %d: %+v", i, report)
+		}
+	}
+	if last := progress[16]; last.Bytes != int64(len(p)) || last.Throughput() < 0 {
+		t.Fatalf("unexpected final progress report: %+v", last)
+	}
+}
+
+func TestFromReaderContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var digested int64
+	_, err := SHA256.FromReaderContext(ctx, endlessReader{}, ReaderOptions{
+		Progress: func(p Progress) {
+			digested = p.Bytes
+			if p.Bytes >= 1<<20 {
+				cancel()
+			}
+		},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if digested != 1<<20 {
+		t.Fatalf("digesting did not stop promptly: %d bytes", digested)
+	}
+}
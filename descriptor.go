@@ -0,0 +1,114 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"regexp"
+)
+
+var (
+	// ErrDescriptorInvalidSize returned when a descriptor has a negative size.
+	ErrDescriptorInvalidSize = errors.New("invalid descriptor size")
+
+	// ErrDescriptorInvalidMediaType returned when a descriptor media type is
+	// not a valid RFC 6838 media type.
+	ErrDescriptorInvalidMediaType = errors.New("invalid descriptor media type")
+
+	// ErrDigestMismatch returned when content does not match the expected
+	// digest.
+	ErrDigestMismatch = errors.New("content digest mismatch")
+
+	// ErrSizeMismatch returned when content does not match the expected size.
+	ErrSizeMismatch = errors.New("content size mismatch")
+)
+
+// mediaTypeRegexp matches media types as defined by RFC 6838, following the
+// OCI image specification.
+var mediaTypeRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9!#$&^_.+-]{0,126}/[A-Za-z0-9][A-Za-z0-9!#$&^_.+-]{0,126}$`)
+
+// Descriptor describes content by its media type, digest and size, following
+// the [OCI content descriptor]. Only the properties needed to identify and
+// verify content are included.
+//
+// [OCI content descriptor]: https://github.com/opencontainers/image-spec/blob/v1.0.2/descriptor.md
+type Descriptor struct {
+	// MediaType is the media type of the content. It is optional.
+	MediaType string `json:"mediaType,omitempty"`
+
+	// Digest is the digest of the content.
+	Digest Digest `json:"digest"`
+
+	// Size is the size of the content, in bytes.
+	Size int64 `json:"size"`
+}
+
+// Validate checks that the descriptor has a valid digest, a non-negative size
+// and, if set, a valid media type.
+func (d Descriptor) Validate() error {
+	if err := d.Digest.Validate(); err != nil {
+		return err
+	}
+	if d.Size < 0 {
+		return ErrDescriptorInvalidSize
+	}
+	if d.MediaType != "" && !mediaTypeRegexp.MatchString(d.MediaType) {
+		return ErrDescriptorInvalidMediaType
+	}
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The decoded descriptor is
+// validated, and an error is returned if it is not valid.
+func (d *Descriptor) UnmarshalJSON(p []byte) error {
+	type descriptor Descriptor // avoid recursing into UnmarshalJSON
+	var desc descriptor
+	if err := json.Unmarshal(p, &desc); err != nil {
+		return err
+	}
+	if err := Descriptor(desc).Validate(); err != nil {
+		return err
+	}
+	*d = Descriptor(desc)
+	return nil
+}
+
+// Verify reads rd until io.EOF and checks that its content matches the size
+// and digest of the descriptor. No more than Size+1 bytes are read from rd.
+// Mismatches are reported as a *MismatchError.
+func (d Descriptor) Verify(rd io.Reader) error {
+	if err := d.Validate(); err != nil {
+		return err
+	}
+
+	alg := d.Digest.Algorithm()
+	h := alg.getHash()
+	defer alg.putHash(h)
+
+	// read one byte more than expected to detect longer content, unless
+	// d.Size+1 would overflow
+	if d.Size < math.MaxInt64 {
+		rd = io.LimitReader(rd, d.Size+1)
+	}
+
+	v := newDescriptorVerifier(d.Digest, d.Size, h)
+	if _, err := io.Copy(v, rd); err != nil {
+		return err
+	}
+	return v.Verify()
+}
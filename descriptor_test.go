@@ -0,0 +1,157 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestDescriptorValidate(t *testing.T) {
+	dgst := FromString("hello")
+
+	for _, testcase := range []struct {
+		Name       string
+		Descriptor Descriptor
+		Err        error
+	}{
+		{
+			Name:       "Valid",
+			Descriptor: Descriptor{MediaType: "application/vnd.oci.image.layer.v1.tar+gzip", Digest: dgst, Size: 5},
+		},
+		{
+			Name:       "NoMediaType",
+			Descriptor: Descriptor{Digest: dgst, Size: 5},
+		},
+		{
+			Name:       "InvalidDigest",
+			Descriptor: Descriptor{Digest: "sha256:abc", Size: 5},
+			Err:        ErrDigestInvalidLength,
+		},
+		{
+			Name:       "NegativeSize",
+			Descriptor: Descriptor{Digest: dgst, Size: -1},
+			Err:        ErrDescriptorInvalidSize,
+		},
+		{
+			Name:       "InvalidMediaType",
+			Descriptor: Descriptor{MediaType: "application", Digest: dgst, Size: 5},
+			Err:        ErrDescriptorInvalidMediaType,
+		},
+	} {
+		t.Run(testcase.Name, func(t *testing.T) {
+			if err := testcase.Descriptor.Validate(); !errors.Is(err, testcase.Err) {
+				t.Fatalf("unexpected error: %v != %v", err, testcase.Err)
+			}
+		})
+	}
+}
+
+func TestDescriptorJSON(t *testing.T) {
+	desc := Descriptor{
+		MediaType: "application/octet-stream",
+		Digest:    FromString("hello"),
+		Size:      5,
+	}
+
+	p, err := json.Marshal(desc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"mediaType":"application/octet-stream","digest":"` + desc.Digest.String() + `","size":5}`
+	if string(p) != expected {
+		t.Fatalf("unexpected JSON: %s != %s", p, expected)
+	}
+
+	var decoded Descriptor
+	if err := json.Unmarshal(p, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded != desc {
+		t.Fatalf("unexpected descriptor: %+v != %+v", decoded, desc)
+	}
+
+	if err := json.Unmarshal([]byte(`{"digest":"sha256:abc","size":5}`), &decoded); !errors.Is(err, ErrDigestInvalidLength) {
+		t.Fatalf("expected invalid descriptor to be rejected, got %v", err)
+	}
+}
+
+func TestDescriptorVerify(t *testing.T) {
+	desc := Descriptor{Digest: FromString("hello"), Size: 5}
+
+	for _, testcase := range []struct {
+		Name    string
+		Content io.Reader
+		Err     error
+	}{
+		{Name: "Match", Content: strings.NewReader("hello")},
+		{Name: "Short", Content: strings.NewReader("hell"), Err: ErrSizeMismatch},
+		{Name: "Long", Content: strings.NewReader("hello, world"), Err: ErrSizeMismatch},
+		{Name: "Mismatch", Content: strings.NewReader("world"), Err: ErrDigestMismatch},
+		{Name: "Endless", Content: &endlessReader{}, Err: ErrSizeMismatch},
+	} {
+		t.Run(testcase.Name, func(t *testing.T) {
+			if err := desc.Verify(testcase.Content); !errors.Is(err, testcase.Err) {
+				t.Fatalf("unexpected error: %v != %v", err, testcase.Err)
+			}
+		})
+	}
+}
+
+func TestDescriptorVerifyMaxSize(t *testing.T) {
+	// the content is read, rather than limited to Size+1 bytes, which
+	// overflows
+	desc := Descriptor{Digest: FromString("hello"), Size: math.MaxInt64}
+	err := desc.Verify(strings.NewReader("hello"))
+
+	var mismatch *MismatchError
+	if !errors.As(err, &mismatch) || mismatch.ActualSize != 5 || mismatch.ActualDigest != desc.Digest {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCountingDigester(t *testing.T) {
+	p := bytes.Repeat([]byte("hello"), 1000)
+
+	d := SHA512.CountingDigester()
+	if _, err := io.Copy(d.Hash(), bytes.NewReader(p)); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := Descriptor{Digest: SHA512.FromBytes(p), Size: int64(len(p))}
+	if desc := d.Descriptor(); desc != expected {
+		t.Fatalf("unexpected descriptor: %+v != %+v", desc, expected)
+	}
+
+	d.Hash().Reset()
+	if d.Size() != 0 || d.Digest() != SHA512.FromBytes(nil) {
+		t.Fatalf("unexpected state after reset: %v %d", d.Digest(), d.Size())
+	}
+}
+
+// endlessReader returns zeroes forever.
+type endlessReader struct{}
+
+func (endlessReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
@@ -25,6 +25,19 @@ type Digester interface {
 	Digest() Digest
 }
 
+// CountingDigester is a Digester that also counts the bytes written to its
+// hash. Resetting the hash resets the count.
+type CountingDigester interface {
+	Digester
+
+	// Size returns the number of bytes written to the hash.
+	Size() int64
+
+	// Descriptor returns a Descriptor with the current digest and size. The
+	// media type is left empty.
+	Descriptor() Descriptor
+}
+
 // digester provides a simple digester definition that embeds a hasher.
 type digester struct {
 	alg  Algorithm
@@ -38,3 +51,45 @@ func (d *digester) Hash() hash.Hash {
 func (d *digester) Digest() Digest {
 	return NewDigest(d.alg, d.hash)
 }
+
+// countingDigester is a digester whose hash counts the bytes written to it.
+type countingDigester struct {
+	alg  Algorithm
+	hash *countingHash
+}
+
+func (d *countingDigester) Hash() hash.Hash {
+	return d.hash
+}
+
+func (d *countingDigester) Digest() Digest {
+	return NewDigest(d.alg, d.hash)
+}
+
+func (d *countingDigester) Size() int64 {
+	return d.hash.n
+}
+
+func (d *countingDigester) Descriptor() Descriptor {
+	return Descriptor{
+		Digest: d.Digest(),
+		Size:   d.Size(),
+	}
+}
+
+// countingHash wraps a hash.Hash, counting the bytes written to it.
+type countingHash struct {
+	hash.Hash
+	n int64
+}
+
+func (h *countingHash) Write(p []byte) (int, error) {
+	n, err := h.Hash.Write(p)
+	h.n += int64(n)
+	return n, err
+}
+
+func (h *countingHash) Reset() {
+	h.Hash.Reset()
+	h.n = 0
+}
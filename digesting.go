@@ -0,0 +1,103 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import "io"
+
+// DigestingReader is an io.Reader that calculates the digest and size of the
+// content read through it. The digest is only meaningful once the underlying
+// reader has been read to io.EOF.
+type DigestingReader struct {
+	r io.Reader
+	d CountingDigester
+}
+
+// NewDigestingReader returns a DigestingReader reading from r and digesting
+// with alg. Like Algorithm.Hash, it panics if alg is not available.
+func NewDigestingReader(r io.Reader, alg Algorithm) *DigestingReader {
+	return &DigestingReader{
+		r: r,
+		d: alg.CountingDigester(),
+	}
+}
+
+func (dr *DigestingReader) Read(p []byte) (int, error) {
+	n, err := dr.r.Read(p)
+	dr.d.Hash().Write(p[:n])
+	return n, err
+}
+
+// WriteTo implements io.WriterTo. It uses the io.WriterTo of the underlying
+// reader, or else the io.ReaderFrom of w, if available.
+func (dr *DigestingReader) WriteTo(w io.Writer) (int64, error) {
+	if wt, ok := dr.r.(io.WriterTo); ok {
+		return wt.WriteTo(io.MultiWriter(w, dr.d.Hash()))
+	}
+	return io.Copy(w, io.TeeReader(dr.r, dr.d.Hash()))
+}
+
+// Digest returns the digest of the content read so far.
+func (dr *DigestingReader) Digest() Digest {
+	return dr.d.Digest()
+}
+
+// Size returns the number of bytes read so far.
+func (dr *DigestingReader) Size() int64 {
+	return dr.d.Size()
+}
+
+// DigestingWriter is an io.Writer that calculates the digest and size of the
+// content written through it. Only content accepted by the underlying writer
+// is digested.
+type DigestingWriter struct {
+	w io.Writer
+	d CountingDigester
+}
+
+// NewDigestingWriter returns a DigestingWriter writing to w and digesting with
+// alg. Like Algorithm.Hash, it panics if alg is not available.
+func NewDigestingWriter(w io.Writer, alg Algorithm) *DigestingWriter {
+	return &DigestingWriter{
+		w: w,
+		d: alg.CountingDigester(),
+	}
+}
+
+func (dw *DigestingWriter) Write(p []byte) (int, error) {
+	n, err := dw.w.Write(p)
+	dw.d.Hash().Write(p[:n])
+	return n, err
+}
+
+// ReadFrom implements io.ReaderFrom. It uses the io.ReaderFrom of the
+// underlying writer, or else the io.WriterTo of r, if available. If the
+// underlying io.ReaderFrom fails, the digest may include content that it
+// read but did not write.
+func (dw *DigestingWriter) ReadFrom(r io.Reader) (int64, error) {
+	if rf, ok := dw.w.(io.ReaderFrom); ok {
+		return rf.ReadFrom(io.TeeReader(r, dw.d.Hash()))
+	}
+	return io.Copy(io.MultiWriter(dw.w, dw.d.Hash()), r)
+}
+
+// Digest returns the digest of the content written so far.
+func (dw *DigestingWriter) Digest() Digest {
+	return dw.d.Digest()
+}
+
+// Size returns the number of bytes written so far.
+func (dw *DigestingWriter) Size() int64 {
+	return dw.d.Size()
+}
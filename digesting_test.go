@@ -0,0 +1,117 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"testing"
+)
+
+func TestDigestingReader(t *testing.T) {
+	p := make([]byte, 1<<20)
+	if _, err := rand.Read(p); err != nil {
+		t.Fatal(err)
+	}
+	expected := SHA512.FromBytes(p)
+
+	for _, testcase := range []struct {
+		Name string
+		Copy func(dr *DigestingReader) ([]byte, error)
+	}{
+		{
+			Name: "Read",
+			Copy: func(dr *DigestingReader) ([]byte, error) {
+				return io.ReadAll(struct{ io.Reader }{dr})
+			},
+		},
+		{
+			Name: "WriterTo",
+			Copy: func(dr *DigestingReader) ([]byte, error) {
+				var buf bytes.Buffer
+				_, err := io.Copy(struct{ io.Writer }{&buf}, dr)
+				return buf.Bytes(), err
+			},
+		},
+		{
+			Name: "ReaderFrom",
+			Copy: func(dr *DigestingReader) ([]byte, error) {
+				dr.r = struct{ io.Reader }{dr.r} // hide io.WriterTo
+				var buf bytes.Buffer
+				_, err := io.Copy(&buf, dr)
+				return buf.Bytes(), err
+			},
+		},
+	} {
+		t.Run(testcase.Name, func(t *testing.T) {
+			dr := NewDigestingReader(bytes.NewReader(p), SHA512)
+			content, err := testcase.Copy(dr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(content, p) {
+				t.Fatal("unexpected content")
+			}
+			if dr.Digest() != expected || dr.Size() != int64(len(p)) {
+				t.Fatalf("unexpected digest or size: %v %d", dr.Digest(), dr.Size())
+			}
+		})
+	}
+}
+
+func TestDigestingWriter(t *testing.T) {
+	p := make([]byte, 1<<20)
+	if _, err := rand.Read(p); err != nil {
+		t.Fatal(err)
+	}
+	expected := SHA512.FromBytes(p)
+
+	for _, testcase := range []struct {
+		Name   string
+		Writer func(*bytes.Buffer) io.Writer
+		Reader func() io.Reader
+	}{
+		{
+			Name:   "Write",
+			Writer: func(buf *bytes.Buffer) io.Writer { return struct{ io.Writer }{buf} },
+			Reader: func() io.Reader { return struct{ io.Reader }{bytes.NewReader(p)} },
+		},
+		{
+			Name:   "ReaderFrom",
+			Writer: func(buf *bytes.Buffer) io.Writer { return buf },
+			Reader: func() io.Reader { return struct{ io.Reader }{bytes.NewReader(p)} },
+		},
+		{
+			Name:   "WriterTo",
+			Writer: func(buf *bytes.Buffer) io.Writer { return struct{ io.Writer }{buf} },
+			Reader: func() io.Reader { return bytes.NewReader(p) },
+		},
+	} {
+		t.Run(testcase.Name, func(t *testing.T) {
+			var buf bytes.Buffer
+			dw := NewDigestingWriter(testcase.Writer(&buf), SHA512)
+			if _, err := io.Copy(dw, testcase.Reader()); err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(buf.Bytes(), p) {
+				t.Fatal("unexpected content")
+			}
+			if dw.Digest() != expected || dw.Size() != int64(len(p)) {
+				t.Fatalf("unexpected digest or size: %v %d", dw.Digest(), dw.Size())
+			}
+		})
+	}
+}
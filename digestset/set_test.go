@@ -391,3 +391,21 @@ func BenchmarkShortCode100(b *testing.B) {
 func BenchmarkShortCode1000(b *testing.B) {
 	benchShortCodeNTable(b, 1000, 12)
 }
+
+func TestShortCodeTableDigestShort(t *testing.T) {
+	digests, err := createDigests(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, dgst := range digests {
+		dset := NewSet()
+		if err := dset.Add(dgst); err != nil {
+			t.Fatal(err)
+		}
+		for _, length := range []int{0, 1, 12, 63, 64, 65} {
+			if expected, actual := ShortCodeTable(dset, length)[dgst], dgst.Short(length); actual != expected {
+				t.Fatalf("unexpected short form for length %d: %q != %q", length, actual, expected)
+			}
+		}
+	}
+}
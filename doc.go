@@ -50,12 +50,14 @@
 // writing is complete, calling the Verifier.Verified method will indicate
 // whether or not the stream of bytes matches the target digest.
 //
-// # Missing Features
+// When the size of the content is known as well, NewDescriptorVerifier returns
+// a Verifier that also rejects content exceeding the size as soon as it is
+// written, and whose Verify method details any mismatch in a MismatchError.
 //
-// In addition to the above, we intend to add the following features to this
-// package:
+// # Resuming
 //
-// 1. A Digester type that supports write sink digest calculation.
-//
-// 2. Suspend and resume of ongoing digest calculations to support efficient digest verification in the registry.
+// Digest calculations may be suspended and resumed, for example to continue a
+// chunked upload after a restart without hashing the received content again.
+// Algorithm.ResumableDigester returns a digester whose state can be saved with
+// MarshalBinary and later restored with RestoreDigester.
 package digest
@@ -0,0 +1,98 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"errors"
+	"io"
+	"os"
+)
+
+// ErrFileModified returned when a file is modified while it is being digested.
+var ErrFileModified = errors.New("file modified while digesting")
+
+// mmapThreshold is the minimum size of a regular file before FromOSFile maps
+// it into memory instead of reading it. Mapping small files costs more than
+// it saves.
+const mmapThreshold = 1 << 20
+
+// FromFile returns the canonical digest and size of the file at path. See
+// Algorithm.FromOSFile.
+func FromFile(path string) (Descriptor, error) {
+	return Canonical.FromFile(path)
+}
+
+// FromFile returns the digest and size of the file at path using the
+// algorithm. See Algorithm.FromOSFile.
+func (a Algorithm) FromFile(path string) (Descriptor, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Descriptor{}, err
+	}
+	defer f.Close()
+
+	return a.FromOSFile(f)
+}
+
+// FromOSFile returns the digest and size of f using the algorithm.
+//
+// Regular files are digested from the start, regardless of the current
+// offset, and large ones are memory-mapped where supported. The file is
+// stat'ed before and after digesting, and ErrFileModified is returned if its
+// size or modification time changed in between. Other files, such as pipes,
+// are read from the current offset until io.EOF.
+func (a Algorithm) FromOSFile(f *os.File) (Descriptor, error) {
+	before, err := f.Stat()
+	if err != nil {
+		return Descriptor{}, err
+	}
+	if !before.Mode().IsRegular() {
+		d := a.CountingDigester()
+		if _, err := io.Copy(d.Hash(), f); err != nil {
+			return Descriptor{}, err
+		}
+		return d.Descriptor(), nil
+	}
+
+	h := a.getHash()
+	defer a.putHash(h)
+
+	size := before.Size()
+	mapped := false
+	if size >= mmapThreshold {
+		if mapped, err = hashMapped(h, f, size); err != nil {
+			return Descriptor{}, err
+		}
+	}
+	if !mapped {
+		n, err := io.Copy(h, io.NewSectionReader(f, 0, size))
+		if err != nil {
+			return Descriptor{}, err
+		}
+		if n != size {
+			return Descriptor{}, ErrFileModified
+		}
+	}
+
+	after, err := f.Stat()
+	if err != nil {
+		return Descriptor{}, err
+	}
+	if after.Size() != size || !after.ModTime().Equal(before.ModTime()) {
+		return Descriptor{}, ErrFileModified
+	}
+
+	return Descriptor{Digest: NewDigest(a, h), Size: size}, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package digest
+
+import (
+	"fmt"
+	"hash"
+	"os"
+	"runtime/debug"
+	"syscall"
+)
+
+// hashMapped writes the first size bytes of f to h by mapping them into
+// memory. It reports false if the file cannot be mapped, in which case the
+// caller should read it instead.
+func hashMapped(h hash.Hash, f *os.File, size int64) (mapped bool, err error) {
+	if int64(int(size)) != size {
+		return false, nil
+	}
+	rc, err := f.SyscallConn()
+	if err != nil {
+		return false, nil
+	}
+
+	var (
+		data    []byte
+		mmapErr error
+	)
+	if err := rc.Control(func(fd uintptr) {
+		data, mmapErr = syscall.Mmap(int(fd), 0, int(size), syscall.PROT_READ, syscall.MAP_SHARED)
+	}); err != nil || mmapErr != nil {
+		return false, nil
+	}
+	defer syscall.Munmap(data)
+	_ = syscall.Madvise(data, syscall.MADV_SEQUENTIAL)
+
+	// Accessing pages beyond the end of a file truncated while it is mapped
+	// raises SIGBUS. Turn it into a panic, and the panic into an error.
+	defer debug.SetPanicOnFault(debug.SetPanicOnFault(true))
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(interface{ Addr() uintptr }); !ok {
+				panic(r)
+			}
+			err = fmt.Errorf("%w: %v", ErrFileModified, r)
+		}
+	}()
+
+	h.Write(data)
+	return true, nil
+}
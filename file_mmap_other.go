@@ -0,0 +1,27 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !linux
+
+package digest
+
+import (
+	"hash"
+	"os"
+)
+
+// hashMapped reports false, as files are only mapped into memory on Linux.
+func hashMapped(h hash.Hash, f *os.File, size int64) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,170 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"crypto"
+	"crypto/rand"
+	"errors"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFromFile(t *testing.T) {
+	for _, size := range []int{0, 1234, mmapThreshold, 3*mmapThreshold + 1} {
+		p := make([]byte, size)
+		if _, err := rand.Read(p); err != nil {
+			t.Fatal(err)
+		}
+		path := filepath.Join(t.TempDir(), "file")
+		if err := os.WriteFile(path, p, 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		desc, err := SHA512.FromFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := Descriptor{Digest: SHA512.FromBytes(p), Size: int64(size)}
+		if desc != expected {
+			t.Fatalf("unexpected descriptor for %d bytes: %+v != %+v", size, desc, expected)
+		}
+	}
+}
+
+func TestFromOSFileOffset(t *testing.T) {
+	p := []byte("hello, world")
+	f, err := os.CreateTemp(t.TempDir(), "file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.Write(p); err != nil {
+		t.Fatal(err)
+	}
+
+	// regular files are digested from the start, regardless of the offset
+	desc, err := Canonical.FromOSFile(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := (Descriptor{Digest: FromBytes(p), Size: int64(len(p))}); desc != expected {
+		t.Fatalf("unexpected descriptor: %+v != %+v", desc, expected)
+	}
+}
+
+func TestFromOSFilePipe(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	p := make([]byte, 3*mmapThreshold)
+	go func() {
+		io.Copy(w, &io.LimitedReader{R: endlessReader{}, N: int64(len(p))})
+		w.Close()
+	}()
+
+	desc, err := Canonical.FromOSFile(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := (Descriptor{Digest: FromBytes(p), Size: int64(len(p))}); desc != expected {
+		t.Fatalf("unexpected descriptor: %+v != %+v", desc, expected)
+	}
+}
+
+func TestFromOSFileModified(t *testing.T) {
+	const alg = Algorithm("test-file-modified")
+	RegisterAlgorithm(alg, modifyingHash{})
+	defer func() { modifyFile = nil }()
+
+	for _, testcase := range []struct {
+		Name   string
+		Size   int
+		Modify func(f *os.File) error
+	}{
+		{
+			// the read stops short of the size stat'ed before
+			Name:   "TruncatedRead",
+			Size:   mmapThreshold / 2,
+			Modify: func(f *os.File) error { return f.Truncate(mmapThreshold / 4) },
+		},
+		{
+			// the file is stat'ed with another size after the read
+			Name: "ExtendedRead",
+			Size: 1234,
+			Modify: func(f *os.File) error {
+				_, err := f.WriteAt([]byte("more"), 1234)
+				return err
+			},
+		},
+		{
+			// accessing the mapped pages past the end raises SIGBUS on Linux
+			Name:   "TruncatedMapped",
+			Size:   3 * mmapThreshold,
+			Modify: func(f *os.File) error { return f.Truncate(0) },
+		},
+	} {
+		t.Run(testcase.Name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "file")
+			if err := os.WriteFile(path, make([]byte, testcase.Size), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			f, err := os.OpenFile(path, os.O_RDWR, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			var modifyErr error
+			modifyFile = func() { modifyErr = testcase.Modify(f) }
+			if _, err := alg.FromOSFile(f); !errors.Is(err, ErrFileModified) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if modifyErr != nil {
+				t.Fatal(modifyErr)
+			}
+		})
+	}
+}
+
+// modifyFile is called by the first write to a hash of modifyingHash, and then
+// cleared.
+var modifyFile func()
+
+// modifyingHash is a CryptoHash whose hashes call modifyFile, to modify a file
+// while it is being digested.
+type modifyingHash struct{}
+
+func (modifyingHash) Available() bool { return true }
+func (modifyingHash) Size() int       { return crypto.SHA256.Size() }
+func (modifyingHash) New() hash.Hash {
+	return modifyingWriter{crypto.SHA256.New()}
+}
+
+type modifyingWriter struct{ hash.Hash }
+
+func (w modifyingWriter) Write(p []byte) (int, error) {
+	if modify := modifyFile; modify != nil {
+		modifyFile = nil
+		modify()
+	}
+	return w.Hash.Write(p)
+}
@@ -0,0 +1,100 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ShortLength is the length of the short form used when logging digests.
+const ShortLength = 12
+
+// Short returns the short form of the digest: the first length characters of
+// the encoded portion. If the encoded portion is not longer than length, the
+// full digest is returned instead. This matches the codes produced by
+// digestset.ShortCodeTable for a set holding only d.
+//
+// Digests without a ':' separator are returned unmodified.
+func (d Digest) Short(length int) string {
+	_, encoded, ok := strings.Cut(string(d), ":")
+	if !ok {
+		return string(d)
+	}
+	if length < 0 {
+		length = 0
+	}
+	if len(encoded) <= length {
+		return string(d)
+	}
+	return encoded[:length]
+}
+
+// Format implements fmt.Formatter. In addition to the usual string verbs, it
+// supports the following forms:
+//
+//	%v, %s     sha256:7173b809ca12ec5dee4506cd86be934c4596dd234ee82c0662eac04a8c2c71dc
+//	%.12v      7173b809ca12
+//	%+v        algorithm=sha256 encoded=7173b809ca12ec5dee4506cd86be934c4596dd234ee82c0662eac04a8c2c71dc
+//	%+.12v     algorithm=sha256 short=7173b809ca12
+//
+// The precision selects the short form, as returned by Digest.Short. Width
+// and the '-' flag pad the result as they would for a string. Digests without
+// a ':' separator are always printed as is.
+func (d Digest) Format(f fmt.State, verb rune) {
+	if (verb != 'v' && verb != 's') || f.Flag('#') {
+		fmt.Fprintf(f, formatDirective(f, verb, true, true), string(d))
+		return
+	}
+
+	s := string(d)
+	if alg, encoded, ok := strings.Cut(s, ":"); ok {
+		precision, short := f.Precision()
+		switch {
+		case f.Flag('+') && short:
+			s = "algorithm=" + alg + " short=" + d.Short(precision)
+		case f.Flag('+'):
+			s = "algorithm=" + alg + " encoded=" + encoded
+		case short:
+			s = d.Short(precision)
+		}
+	}
+	fmt.Fprintf(f, formatDirective(f, 's', false, false), s)
+}
+
+// formatDirective rebuilds the directive that resulted in a call to Format,
+// optionally keeping the '+' flag and the precision.
+func formatDirective(f fmt.State, verb rune, plus, precision bool) string {
+	var b strings.Builder
+	b.WriteByte('%')
+	for _, flag := range "+-# 0" {
+		if flag == '+' && !plus {
+			continue
+		}
+		if f.Flag(int(flag)) {
+			b.WriteRune(flag)
+		}
+	}
+	if width, ok := f.Width(); ok {
+		b.WriteString(strconv.Itoa(width))
+	}
+	if p, ok := f.Precision(); ok && precision {
+		b.WriteByte('.')
+		b.WriteString(strconv.Itoa(p))
+	}
+	b.WriteRune(verb)
+	return b.String()
+}
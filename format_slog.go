@@ -0,0 +1,37 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.21
+
+package digest
+
+import (
+	"log/slog"
+	"strings"
+)
+
+// LogValue implements slog.LogValuer. The digest is logged as a group with
+// the algorithm, the encoded portion and the short form (see Digest.Short).
+// Digests without a ':' separator are logged as a plain string.
+func (d Digest) LogValue() slog.Value {
+	alg, encoded, ok := strings.Cut(string(d), ":")
+	if !ok {
+		return slog.StringValue(string(d))
+	}
+	return slog.GroupValue(
+		slog.String("algorithm", alg),
+		slog.String("encoded", encoded),
+		slog.String("short", d.Short(ShortLength)),
+	)
+}
@@ -0,0 +1,44 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.21
+
+package digest
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+)
+
+func TestDigestLogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+
+	logger.Info("pulled", "digest", Digest("sha256:7173b809ca12ec5dee4506cd86be934c4596dd234ee82c0662eac04a8c2c71dc"))
+	logger.Info("pulled", "digest", Digest("invalid"))
+
+	expected := "level=INFO msg=pulled digest.algorithm=sha256 digest.encoded=7173b809ca12ec5dee4506cd86be934c4596dd234ee82c0662eac04a8c2c71dc digest.short=7173b809ca12\n" +
+		"level=INFO msg=pulled digest=invalid\n"
+	if buf.String() != expected {
+		t.Fatalf("unexpected log output:\n%s\nexpected:\n%s", buf.String(), expected)
+	}
+}
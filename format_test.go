@@ -0,0 +1,53 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDigestFormat(t *testing.T) {
+	const (
+		dgst    = Digest("sha256:7173b809ca12ec5dee4506cd86be934c4596dd234ee82c0662eac04a8c2c71dc")
+		encoded = "7173b809ca12ec5dee4506cd86be934c4596dd234ee82c0662eac04a8c2c71dc"
+	)
+
+	for _, testcase := range []struct {
+		Format   string
+		Digest   Digest
+		Expected string
+	}{
+		{Format: "%v", Digest: dgst, Expected: string(dgst)},
+		{Format: "%s", Digest: dgst, Expected: string(dgst)},
+		{Format: "%q", Digest: dgst, Expected: `"` + string(dgst) + `"`},
+		{Format: "%#v", Digest: dgst, Expected: `"` + string(dgst) + `"`},
+		{Format: "%.12v", Digest: dgst, Expected: "7173b809ca12"},
+		{Format: "%.12s", Digest: dgst, Expected: "7173b809ca12"},
+		{Format: "%.64v", Digest: dgst, Expected: string(dgst)},
+		{Format: "%+v", Digest: dgst, Expected: "algorithm=sha256 encoded=" + encoded},
+		{Format: "%+.12v", Digest: dgst, Expected: "algorithm=sha256 short=7173b809ca12"},
+		{Format: "%14.12v|", Digest: dgst, Expected: "  7173b809ca12|"},
+		{Format: "%-14.12v|", Digest: dgst, Expected: "7173b809ca12  |"},
+		{Format: "%.12v", Digest: "invalid", Expected: "invalid"},
+		{Format: "%+v", Digest: "invalid", Expected: "invalid"},
+	} {
+		t.Run(testcase.Format, func(t *testing.T) {
+			if actual := fmt.Sprintf(testcase.Format, testcase.Digest); actual != testcase.Expected {
+				t.Fatalf("unexpected output for %q: %q != %q", testcase.Format, actual, testcase.Expected)
+			}
+		})
+	}
+}
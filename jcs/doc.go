@@ -0,0 +1,23 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jcs digests JSON documents in the JSON Canonicalization Scheme
+// (JCS) of RFC 8785, so that documents differing only in key order,
+// whitespace, number notation or string escaping share a digest.
+//
+// Canonicalization sorts object members by the UTF-16 code units of their
+// names, formats numbers like ECMAScript, and escapes strings minimally. Input
+// must be I-JSON (RFC 7493): valid UTF-8, without duplicate member names, and
+// with numbers representable as IEEE 754 double precision values.
+package jcs
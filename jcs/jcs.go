@@ -0,0 +1,342 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jcs
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/opencontainers/go-digest"
+)
+
+var (
+	// ErrInvalidJSON is returned for input that is not valid JSON.
+	ErrInvalidJSON = errors.New("invalid JSON")
+
+	// ErrInvalidUTF8 is returned for input that is not valid UTF-8.
+	ErrInvalidUTF8 = errors.New("invalid UTF-8 in JSON")
+
+	// ErrDuplicateKey is returned for objects with duplicate member names.
+	ErrDuplicateKey = errors.New("duplicate key in JSON object")
+
+	// ErrNumberRange is returned for numbers that cannot be represented as
+	// IEEE 754 double precision values.
+	ErrNumberRange = errors.New("JSON number out of range")
+)
+
+// Canonicalize returns the canonical form of the JSON document p.
+func Canonicalize(p []byte) ([]byte, error) {
+	if !utf8.Valid(p) {
+		return nil, ErrInvalidUTF8
+	}
+	if err := checkSurrogates(p); err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(p))
+	dec.UseNumber()
+
+	var buf bytes.Buffer
+	if err := writeValue(&buf, dec); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("%w: unexpected data after top-level value", ErrInvalidJSON)
+	}
+	return buf.Bytes(), nil
+}
+
+// FromJSONBytes returns the digest of the canonical form of the JSON document
+// p, using the algorithm.
+func FromJSONBytes(alg digest.Algorithm, p []byte) (digest.Digest, error) {
+	canonical, err := Canonicalize(p)
+	if err != nil {
+		return "", err
+	}
+	return alg.FromBytes(canonical), nil
+}
+
+// FromCanonicalJSON returns the digest of the canonical form of the JSON
+// encoding of v, as returned by json.Marshal, using the algorithm.
+func FromCanonicalJSON(alg digest.Algorithm, v interface{}) (digest.Digest, error) {
+	p, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return FromJSONBytes(alg, p)
+}
+
+// NewVerifier returns a digest.Verifier for the canonical form of the JSON
+// document written to it. The document is buffered until Verified is called,
+// which reports false for invalid JSON. Like digest.Digest.Verifier, it
+// panics if the algorithm of d is not available.
+func NewVerifier(d digest.Digest) digest.Verifier {
+	d.Algorithm().Hash() // panic early, like digest.Digest.Verifier
+	return &verifier{digest: d}
+}
+
+type verifier struct {
+	digest digest.Digest
+	buf    bytes.Buffer
+}
+
+func (v *verifier) Write(p []byte) (int, error) {
+	return v.buf.Write(p)
+}
+
+func (v *verifier) Verified() bool {
+	d, err := FromJSONBytes(v.digest.Algorithm(), v.buf.Bytes())
+	return err == nil && d == v.digest
+}
+
+// checkSurrogates returns an error if a string of the JSON document p escapes
+// an unpaired UTF-16 surrogate, such as "\ud800". I-JSON forbids them, and
+// encoding/json would silently replace them with U+FFFD, so that different
+// documents would share a canonical form.
+func checkSurrogates(p []byte) error {
+	inString := false
+	for i := 0; i < len(p); i++ {
+		switch {
+		case p[i] == '"':
+			inString = !inString
+		case inString && p[i] == '\\':
+			i++ // skip the escaped character
+			if i >= len(p) || p[i] != 'u' {
+				continue
+			}
+
+			r := escapedRune(p[i+1:])
+			switch {
+			case utf16.IsSurrogate(r) && r < 0xdc00:
+				// a high surrogate must be followed by an escaped low one
+				if len(p) < i+11 || p[i+5] != '\\' || p[i+6] != 'u' {
+					return fmt.Errorf("%w: unpaired surrogate %q", ErrInvalidJSON, p[i-1:i+5])
+				}
+				if low := escapedRune(p[i+7:]); !utf16.IsSurrogate(low) || low < 0xdc00 {
+					return fmt.Errorf("%w: unpaired surrogate %q", ErrInvalidJSON, p[i-1:i+5])
+				}
+				i += 10
+			case utf16.IsSurrogate(r):
+				return fmt.Errorf("%w: unpaired surrogate %q", ErrInvalidJSON, p[i-1:i+5])
+			}
+		}
+	}
+	return nil
+}
+
+// escapedRune decodes the four hex digits at the start of p, as they follow
+// "\u" in a JSON string. It returns -1 if they are malformed, which is left
+// to the decoder to report.
+func escapedRune(p []byte) rune {
+	if len(p) < 4 {
+		return -1
+	}
+	r, err := strconv.ParseUint(string(p[:4]), 16, 16)
+	if err != nil {
+		return -1
+	}
+	return rune(r)
+}
+
+// writeValue writes the canonical form of the next value of dec to buf.
+func writeValue(buf *bytes.Buffer, dec *json.Decoder) error {
+	tok, err := dec.Token()
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return fmt.Errorf("%w: %v", ErrInvalidJSON, err)
+	}
+
+	switch tok := tok.(type) {
+	case json.Delim:
+		switch tok {
+		case '{':
+			return writeObject(buf, dec)
+		case '[':
+			return writeArray(buf, dec)
+		}
+		return fmt.Errorf("%w: unexpected %q", ErrInvalidJSON, tok)
+	case string:
+		writeString(buf, tok)
+	case json.Number:
+		f, err := strconv.ParseFloat(string(tok), 64)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrNumberRange, tok)
+		}
+		s, err := formatNumber(f)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(s)
+	case bool:
+		buf.WriteString(strconv.FormatBool(tok))
+	case nil:
+		buf.WriteString("null")
+	}
+	return nil
+}
+
+// writeObject writes the canonical form of the members of an object, after
+// its opening delimiter was read from dec.
+func writeObject(buf *bytes.Buffer, dec *json.Decoder) error {
+	type member struct {
+		name  string
+		key   []uint16
+		value []byte
+	}
+
+	var (
+		members []member
+		names   = map[string]struct{}{}
+	)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidJSON, err)
+		}
+		name := tok.(string) // the decoder ensures names are strings
+		if _, ok := names[name]; ok {
+			return fmt.Errorf("%w: %q", ErrDuplicateKey, name)
+		}
+		names[name] = struct{}{}
+
+		var value bytes.Buffer
+		if err := writeValue(&value, dec); err != nil {
+			return err
+		}
+		members = append(members, member{
+			name:  name,
+			key:   utf16.Encode([]rune(name)),
+			value: value.Bytes(),
+		})
+	}
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidJSON, err)
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		return lessUTF16(members[i].key, members[j].key)
+	})
+
+	buf.WriteByte('{')
+	for i, m := range members {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		writeString(buf, m.name)
+		buf.WriteByte(':')
+		buf.Write(m.value)
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+// writeArray writes the canonical form of the elements of an array, after its
+// opening delimiter was read from dec.
+func writeArray(buf *bytes.Buffer, dec *json.Decoder) error {
+	buf.WriteByte('[')
+	for i := 0; dec.More(); i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := writeValue(buf, dec); err != nil {
+			return err
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidJSON, err)
+	}
+	buf.WriteByte(']')
+	return nil
+}
+
+// lessUTF16 compares strings as sequences of UTF-16 code units.
+func lessUTF16(a, b []uint16) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
+
+// writeString writes s as a JSON string, escaping only what ECMAScript's
+// JSON.stringify escapes.
+func writeString(buf *bytes.Buffer, s string) {
+	const hex = "0123456789abcdef"
+
+	buf.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch c {
+		case '"', '\\':
+			buf.WriteByte('\\')
+			buf.WriteByte(c)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if c < 0x20 {
+				buf.WriteString(`\u00`)
+				buf.WriteByte(hex[c>>4])
+				buf.WriteByte(hex[c&0xf])
+			} else {
+				buf.WriteByte(c)
+			}
+		}
+	}
+	buf.WriteByte('"')
+}
+
+// formatNumber formats f like ECMAScript's Number.prototype.toString: the
+// shortest representation that round trips, in decimal notation for
+// magnitudes in [1e-6, 1e21) and in exponential notation otherwise.
+func formatNumber(f float64) (string, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", fmt.Errorf("%w: %v", ErrNumberRange, f)
+	}
+	if f == 0 {
+		return "0", nil // including negative zero
+	}
+
+	format := byte('f')
+	if abs := math.Abs(f); abs < 1e-6 || abs >= 1e21 {
+		format = 'e'
+	}
+	s := strconv.FormatFloat(f, format, -1, 64)
+	if format == 'e' {
+		// ECMAScript does not pad exponents: 1e-07 becomes 1e-7
+		if n := len(s); n >= 4 && s[n-4] == 'e' && s[n-3] == '-' && s[n-2] == '0' {
+			s = s[:n-2] + s[n-1:]
+		}
+	}
+	return s, nil
+}
@@ -0,0 +1,242 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jcs
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+)
+
+func TestCanonicalize(t *testing.T) {
+	for _, testcase := range []struct {
+		Name     string
+		Input    string
+		Expected string
+		Err      error
+	}{
+		{
+			// RFC 8785, section 3.2.2
+			Name: "RFC8785Example",
+			Input: `{
+  "numbers": [333333333.33333329, 1E30, 4.50,
+              2e-3, 0.000000000000000000000000001],
+  "string": "\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/",
+  "literals": [null, true, false]
+}`,
+			Expected: `{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],"string":"€$\u000f\nA'B\"\\\\\"/"}`,
+		},
+		{
+			// RFC 8785, section 3.2.3
+			Name: "RFC8785Sorting",
+			Input: `{
+  "\u20ac": "Euro Sign",
+  "\r": "Carriage Return",
+  "\ufb33": "Hebrew Letter Dalet With Dagesh",
+  "1": "One",
+  "\ud83d\ude00": "Emoji: Grinning Face",
+  "\u0080": "Control",
+  "\u00f6": "Latin Small Letter O With Diaeresis"
+}`,
+			Expected: "{\"\\r\":\"Carriage Return\",\"1\":\"One\",\"\u0080\":\"Control\",\"ö\":\"Latin Small Letter O With Diaeresis\",\"€\":\"Euro Sign\",\"😀\":\"Emoji: Grinning Face\",\"\ufb33\":\"Hebrew Letter Dalet With Dagesh\"}",
+		},
+		{
+			Name:     "Nested",
+			Input:    ` { "b" : [ {"d":1,"c":[]}, {} ], "a" : "<&>\u2028" } `,
+			Expected: "{\"a\":\"<&>\u2028\",\"b\":[{\"c\":[],\"d\":1},{}]}",
+		},
+		{
+			Name:     "Scalar",
+			Input:    "-0.0",
+			Expected: "0",
+		},
+		{
+			Name:  "DuplicateKey",
+			Input: `{"a":1,"b":{"c":1,"c":2}}`,
+			Err:   ErrDuplicateKey,
+		},
+		{
+			Name:  "InvalidUTF8",
+			Input: "\"\xff\"",
+			Err:   ErrInvalidUTF8,
+		},
+		{
+			Name:     "SurrogatePair",
+			Input:    `["\ud83d\ude00", "\\ud800"]`,
+			Expected: `["😀","\\ud800"]`,
+		},
+		{
+			Name:  "LoneHighSurrogate",
+			Input: `["\ud800"]`,
+			Err:   ErrInvalidJSON,
+		},
+		{
+			Name:  "LoneLowSurrogate",
+			Input: `["\udc00"]`,
+			Err:   ErrInvalidJSON,
+		},
+		{
+			Name:  "ReversedSurrogates",
+			Input: `{"a":"\ude00\ud83d"}`,
+			Err:   ErrInvalidJSON,
+		},
+		{
+			Name:  "HighSurrogateBeforeText",
+			Input: `["\ud83dx\ude00"]`,
+			Err:   ErrInvalidJSON,
+		},
+		{
+			Name:  "NumberRange",
+			Input: "1e400",
+			Err:   ErrNumberRange,
+		},
+		{
+			Name:  "Trailing",
+			Input: "{} {}",
+			Err:   ErrInvalidJSON,
+		},
+		{
+			Name:  "Truncated",
+			Input: `{"a":[1,`,
+			Err:   ErrInvalidJSON,
+		},
+		{
+			Name:  "Empty",
+			Input: "",
+			Err:   ErrInvalidJSON,
+		},
+	} {
+		t.Run(testcase.Name, func(t *testing.T) {
+			p, err := Canonicalize([]byte(testcase.Input))
+			if !errors.Is(err, testcase.Err) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err == nil && string(p) != testcase.Expected {
+				t.Fatalf("unexpected canonical form: %s != %s", p, testcase.Expected)
+			}
+		})
+	}
+}
+
+// TestFormatNumber checks the number serialization samples of RFC 8785,
+// appendix B.
+func TestFormatNumber(t *testing.T) {
+	for _, testcase := range []struct {
+		Bits     uint64
+		Expected string
+	}{
+		{0x0000000000000000, "0"},
+		{0x8000000000000000, "0"},
+		{0x0000000000000001, "5e-324"},
+		{0x8000000000000001, "-5e-324"},
+		{0x7fefffffffffffff, "1.7976931348623157e+308"},
+		{0xffefffffffffffff, "-1.7976931348623157e+308"},
+		{0x4340000000000000, "9007199254740992"},
+		{0xc340000000000000, "-9007199254740992"},
+		{0x4430000000000000, "295147905179352830000"},
+		{0x44b52d02c7e14af5, "9.999999999999997e+22"},
+		{0x44b52d02c7e14af6, "1e+23"},
+		{0x44b52d02c7e14af7, "1.0000000000000001e+23"},
+		{0x444b1ae4d6e2ef4e, "999999999999999700000"},
+		{0x444b1ae4d6e2ef4f, "999999999999999900000"},
+		{0x444b1ae4d6e2ef50, "1e+21"},
+		{0x3eb0c6f7a0b5ed8c, "9.999999999999997e-7"},
+		{0x3eb0c6f7a0b5ed8d, "0.000001"},
+		{0x41b3de4355555553, "333333333.3333332"},
+		{0x41b3de4355555554, "333333333.33333325"},
+		{0x41b3de4355555555, "333333333.3333333"},
+		{0x41b3de4355555556, "333333333.3333334"},
+		{0x41b3de4355555557, "333333333.33333343"},
+		{0xbecbf647612f3696, "-0.0000033333333333333333"},
+		{0x43143ff3c1cb0959, "1424953923781206.2"},
+	} {
+		s, err := formatNumber(math.Float64frombits(testcase.Bits))
+		if err != nil {
+			t.Fatalf("unexpected error formatting %016x: %v", testcase.Bits, err)
+		}
+		if s != testcase.Expected {
+			t.Fatalf("unexpected format of %016x: %s != %s", testcase.Bits, s, testcase.Expected)
+		}
+	}
+
+	for _, f := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		if _, err := formatNumber(f); !errors.Is(err, ErrNumberRange) {
+			t.Fatalf("unexpected error formatting %v: %v", f, err)
+		}
+	}
+}
+
+func TestFromCanonicalJSON(t *testing.T) {
+	type config struct {
+		Name   string            `json:"name"`
+		Labels map[string]string `json:"labels"`
+		Ratio  float64           `json:"ratio"`
+	}
+
+	d, err := FromCanonicalJSON(digest.SHA256, config{
+		Name:   "a<b",
+		Labels: map[string]string{"y": "1", "x": "2"},
+		Ratio:  0.5,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := digest.SHA256.FromString(`{"labels":{"x":"2","y":"1"},"name":"a<b","ratio":0.5}`)
+	if d != expected {
+		t.Fatalf("unexpected digest: %v != %v", d, expected)
+	}
+
+	other, err := FromJSONBytes(digest.SHA256, []byte(`{ "ratio": 5e-1, "name": "a\u003cb", "labels": {"y":"1","x":"2"} }`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other != d {
+		t.Fatalf("unexpected digest: %v != %v", other, d)
+	}
+
+	if _, err := FromCanonicalJSON(digest.SHA256, math.Inf(1)); err == nil {
+		t.Fatal("expected error encoding infinity")
+	}
+}
+
+func TestVerifier(t *testing.T) {
+	d := digest.SHA256.FromString(`{"a":1,"b":[true]}`)
+
+	for _, testcase := range []struct {
+		Input    string
+		Verified bool
+	}{
+		{Input: `{"a":1,"b":[true]}`, Verified: true},
+		{Input: "{\n  \"b\": [true],\n  \"a\": 1.0\n}", Verified: true},
+		{Input: `{"a":2,"b":[true]}`},
+		{Input: `{"a":1,"b":[true]`},
+	} {
+		v := NewVerifier(d)
+		// write in pieces, as a stream would
+		for i := 0; i < len(testcase.Input); i += 3 {
+			end := i + 3
+			if end > len(testcase.Input) {
+				end = len(testcase.Input)
+			}
+			v.Write([]byte(testcase.Input[i:end]))
+		}
+		if v.Verified() != testcase.Verified {
+			t.Fatalf("unexpected verification of %q: %v", testcase.Input, !testcase.Verified)
+		}
+	}
+}
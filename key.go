@@ -0,0 +1,151 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"encoding/binary"
+	"hash"
+	"sort"
+)
+
+// keyVersion is the version of the KeyBuilder encoding.
+const keyVersion = 1
+
+// Field tags of the KeyBuilder encoding.
+const (
+	keyTagString = 'S'
+	keyTagBytes  = 'B'
+	keyTagInt    = 'I'
+	keyTagUint   = 'U'
+	keyTagDigest = 'D'
+	keyTagMap    = 'M'
+)
+
+// KeyBuilder derives a digest from a sequence of typed fields, such as a
+// cache key. Unlike concatenating strings, every field is tagged with its type
+// and length-prefixed, so different sequences of fields never share an
+// encoding, and keys built for different domains never collide.
+//
+// The encoding is stable. It starts with the version byte 0x01 and the length
+// and bytes of the domain, followed by each field as a one byte tag and its
+// value:
+//
+//	'S' string   uvarint length, bytes
+//	'B' bytes    uvarint length, bytes
+//	'I' int      8 bytes, big-endian two's complement
+//	'U' uint     8 bytes, big-endian
+//	'D' digest   uvarint length, bytes of the digest string
+//	'M' map      uvarint number of entries, then for each entry in
+//	             increasing order of keys: uvarint length and bytes of
+//	             the key, uvarint length and bytes of the value
+//
+// Lengths are unsigned varints as encoded by binary.PutUvarint.
+type KeyBuilder struct {
+	alg  Algorithm
+	hash hash.Hash
+	buf  [binary.MaxVarintLen64]byte
+}
+
+// NewKeyBuilder returns a KeyBuilder for keys of domain, digesting with alg.
+// Like Algorithm.Hash, it panics if alg is not available.
+func NewKeyBuilder(alg Algorithm, domain string) *KeyBuilder {
+	b := &KeyBuilder{
+		alg:  alg,
+		hash: alg.Hash(),
+	}
+	b.hash.Write([]byte{keyVersion})
+	b.writeString(domain)
+	return b
+}
+
+// AddString adds a string field.
+func (b *KeyBuilder) AddString(s string) *KeyBuilder {
+	b.writeTag(keyTagString)
+	b.writeString(s)
+	return b
+}
+
+// AddBytes adds a bytes field. A nil and an empty slice are encoded alike.
+func (b *KeyBuilder) AddBytes(p []byte) *KeyBuilder {
+	b.writeTag(keyTagBytes)
+	b.writeUvarint(uint64(len(p)))
+	b.hash.Write(p)
+	return b
+}
+
+// AddInt adds a signed integer field.
+func (b *KeyBuilder) AddInt(i int64) *KeyBuilder {
+	b.writeTag(keyTagInt)
+	b.writeUint64(uint64(i))
+	return b
+}
+
+// AddUint adds an unsigned integer field.
+func (b *KeyBuilder) AddUint(u uint64) *KeyBuilder {
+	b.writeTag(keyTagUint)
+	b.writeUint64(u)
+	return b
+}
+
+// AddDigest adds a digest field, such as the key of a dependency.
+func (b *KeyBuilder) AddDigest(d Digest) *KeyBuilder {
+	b.writeTag(keyTagDigest)
+	b.writeString(string(d))
+	return b
+}
+
+// AddMap adds a map field. Entries are encoded in increasing order of keys,
+// so the field does not depend on the iteration order of m.
+func (b *KeyBuilder) AddMap(m map[string]string) *KeyBuilder {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	b.writeTag(keyTagMap)
+	b.writeUvarint(uint64(len(keys)))
+	for _, k := range keys {
+		b.writeString(k)
+		b.writeString(m[k])
+	}
+	return b
+}
+
+// Digest returns the digest of the fields added so far. More fields may be
+// added afterwards.
+func (b *KeyBuilder) Digest() Digest {
+	return NewDigest(b.alg, b.hash)
+}
+
+func (b *KeyBuilder) writeTag(tag byte) {
+	b.buf[0] = tag
+	b.hash.Write(b.buf[:1])
+}
+
+func (b *KeyBuilder) writeUvarint(u uint64) {
+	n := binary.PutUvarint(b.buf[:], u)
+	b.hash.Write(b.buf[:n])
+}
+
+func (b *KeyBuilder) writeUint64(u uint64) {
+	binary.BigEndian.PutUint64(b.buf[:8], u)
+	b.hash.Write(b.buf[:8])
+}
+
+func (b *KeyBuilder) writeString(s string) {
+	b.writeUvarint(uint64(len(s)))
+	b.hash.Write([]byte(s))
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import "testing"
+
+func TestKeyBuilderEncoding(t *testing.T) {
+	key := NewKeyBuilder(SHA256, "build").
+		AddString("go").
+		AddBytes([]byte{0xff}).
+		AddInt(-1).
+		AddUint(2).
+		AddDigest("sha256:00").
+		AddMap(map[string]string{"b": "2", "a": "1"}).
+		Digest()
+
+	encoding := []byte{
+		0x01, 5, 'b', 'u', 'i', 'l', 'd',
+		'S', 2, 'g', 'o',
+		'B', 1, 0xff,
+		'I', 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		'U', 0, 0, 0, 0, 0, 0, 0, 2,
+		'D', 9, 's', 'h', 'a', '2', '5', '6', ':', '0', '0',
+		'M', 2, 1, 'a', 1, '1', 1, 'b', 1, '2',
+	}
+	if expected := SHA256.FromBytes(encoding); key != expected {
+		t.Fatalf("unexpected key: %v != %v", key, expected)
+	}
+
+	// the encoding is stable across releases
+	if key != "sha256:d199f00b7452409d71ac0ed214403fd6f5e73f04fc33db38813d26cb9bb82290" {
+		t.Fatalf("unexpected key: %v", key)
+	}
+}
+
+func TestKeyBuilderUnambiguous(t *testing.T) {
+	for _, testcase := range []struct {
+		Name string
+		A, B *KeyBuilder
+	}{
+		{
+			Name: "Concatenation",
+			A:    NewKeyBuilder(SHA256, "test").AddString("ab").AddString("c"),
+			B:    NewKeyBuilder(SHA256, "test").AddString("a").AddString("bc"),
+		},
+		{
+			Name: "Domain",
+			A:    NewKeyBuilder(SHA256, "a").AddString("b"),
+			B:    NewKeyBuilder(SHA256, "ab"),
+		},
+		{
+			Name: "Type",
+			A:    NewKeyBuilder(SHA256, "test").AddString("a"),
+			B:    NewKeyBuilder(SHA256, "test").AddBytes([]byte("a")),
+		},
+		{
+			Name: "Sign",
+			A:    NewKeyBuilder(SHA256, "test").AddInt(1),
+			B:    NewKeyBuilder(SHA256, "test").AddUint(1),
+		},
+		{
+			Name: "Map",
+			A:    NewKeyBuilder(SHA256, "test").AddMap(map[string]string{"a": "b", "c": ""}),
+			B:    NewKeyBuilder(SHA256, "test").AddMap(map[string]string{"a": "bc"}),
+		},
+	} {
+		t.Run(testcase.Name, func(t *testing.T) {
+			if a, b := testcase.A.Digest(), testcase.B.Digest(); a == b {
+				t.Fatalf("keys collide: %v", a)
+			}
+		})
+	}
+}
+
+func TestKeyBuilderMapOrder(t *testing.T) {
+	m := map[string]string{}
+	for _, k := range []string{"z", "y", "x", "w", "v", "u", "t", "s"} {
+		m[k] = k + k
+	}
+	expected := NewKeyBuilder(SHA256, "test").AddMap(m).Digest()
+	for i := 0; i < 10; i++ {
+		if key := NewKeyBuilder(SHA256, "test").AddMap(m).Digest(); key != expected {
+			t.Fatalf("unexpected key: %v != %v", key, expected)
+		}
+	}
+}
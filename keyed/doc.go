@@ -0,0 +1,33 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package keyed registers keyed digest algorithms, such as HMAC-SHA256, to
+// protect content-addressed stores against poisoning: without the key, nobody
+// can produce content matching a keyed digest.
+//
+// A keyed algorithm is named after its base function and the ID of its key,
+// for example "hmac-sha256.k2024". Once registered, it works with Digest,
+// Digester and Verifier like any other algorithm:
+//
+//	alg, err := keyed.Register(keyed.HMACSHA256, "k2024", key)
+//	if err != nil {
+//		return err
+//	}
+//	d := alg.FromBytes(p) // hmac-sha256.k2024:...
+//
+// Keys are rotated by registering a new key ID, and removing the old one once
+// digests calculated with it are no longer verified. A removed algorithm is
+// not available, and the digest package refuses to calculate digests with it.
+// Key IDs cannot be reused, so that a digest always refers to a single key.
+package keyed
@@ -0,0 +1,167 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyed
+
+import (
+	"crypto"
+	"crypto/hmac"
+	// make sure crypto.SHA256 is registered
+	_ "crypto/sha256"
+	// make sure crypto.SHA512 is registered
+	_ "crypto/sha512"
+	"errors"
+	"fmt"
+	"hash"
+	"regexp"
+	"sync"
+
+	"github.com/opencontainers/go-digest"
+)
+
+var (
+	// ErrBaseUnsupported is returned for unknown base functions.
+	ErrBaseUnsupported = errors.New("unsupported keyed base function")
+
+	// ErrKeyIDInvalid is returned for key IDs that do not make a valid
+	// algorithm name.
+	ErrKeyIDInvalid = errors.New("invalid key ID")
+
+	// ErrKeyIDInUse is returned when registering a key ID that was registered
+	// before, even if it was removed since.
+	ErrKeyIDInUse = errors.New("key ID already registered")
+
+	// ErrKeyEmpty is returned when registering an empty key.
+	ErrKeyEmpty = errors.New("empty key")
+)
+
+// keyIDRegexp matches key IDs that keep the algorithm name conformant to the
+// grammar of the OCI image specification.
+var keyIDRegexp = regexp.MustCompile(`^[a-z0-9]+([+._-][a-z0-9]+)*$`)
+
+// Base identifies the keyed hash function of keyed algorithms.
+type Base string
+
+const (
+	// HMACSHA256 is HMAC (RFC 2104) with SHA-256.
+	HMACSHA256 Base = "hmac-sha256"
+
+	// HMACSHA512 is HMAC (RFC 2104) with SHA-512.
+	HMACSHA512 Base = "hmac-sha512"
+)
+
+var bases = map[Base]crypto.Hash{
+	HMACSHA256: crypto.SHA256,
+	HMACSHA512: crypto.SHA512,
+}
+
+// Algorithm returns the name of the keyed algorithm using the key with the
+// given ID. The algorithm is only available once registered.
+func (b Base) Algorithm(keyID string) digest.Algorithm {
+	return digest.Algorithm(string(b) + "." + keyID)
+}
+
+var (
+	// keys maps keyed algorithms registered by Register to their hashes,
+	// including removed ones.
+	keys = map[digest.Algorithm]*keyedHash{}
+
+	// keysLock protects keys
+	keysLock sync.Mutex
+)
+
+// Register registers the keyed algorithm of base with the key, identified by
+// keyID, and returns it. The key is copied.
+func Register(base Base, keyID string, key []byte) (digest.Algorithm, error) {
+	h, ok := bases[base]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrBaseUnsupported, string(base))
+	}
+	if !keyIDRegexp.MatchString(keyID) {
+		return "", fmt.Errorf("%w: %q", ErrKeyIDInvalid, keyID)
+	}
+	if len(key) == 0 {
+		return "", ErrKeyEmpty
+	}
+
+	alg := base.Algorithm(keyID)
+
+	keysLock.Lock()
+	defer keysLock.Unlock()
+
+	if _, ok := keys[alg]; ok {
+		return "", fmt.Errorf("%w: %s", ErrKeyIDInUse, alg)
+	}
+	kh := &keyedHash{hash: h, key: append([]byte(nil), key...)}
+	if !digest.RegisterAlgorithm(alg, kh) {
+		// registered by another package
+		return "", fmt.Errorf("%w: %s", ErrKeyIDInUse, alg)
+	}
+	keys[alg] = kh
+	return alg, nil
+}
+
+// Remove removes the key of a keyed algorithm, which is no longer available
+// afterwards. It returns false if alg was not registered by Register, or was
+// removed already. Remove must not be called while digests are calculated
+// with the algorithm.
+func Remove(alg digest.Algorithm) bool {
+	keysLock.Lock()
+	kh, ok := keys[alg]
+	keysLock.Unlock()
+	if !ok {
+		return false
+	}
+	return kh.remove()
+}
+
+// keyedHash implements digest.CryptoHash for a keyed algorithm.
+type keyedHash struct {
+	hash crypto.Hash
+
+	mu  sync.RWMutex
+	key []byte // nil once removed
+}
+
+func (kh *keyedHash) Available() bool {
+	kh.mu.RLock()
+	defer kh.mu.RUnlock()
+	return kh.key != nil && kh.hash.Available()
+}
+
+func (kh *keyedHash) Size() int {
+	return kh.hash.Size()
+}
+
+func (kh *keyedHash) New() hash.Hash {
+	kh.mu.RLock()
+	defer kh.mu.RUnlock()
+	if kh.key == nil {
+		panic("keyed algorithm used after its key was removed")
+	}
+	return hmac.New(kh.hash.New, kh.key)
+}
+
+func (kh *keyedHash) remove() bool {
+	kh.mu.Lock()
+	defer kh.mu.Unlock()
+	if kh.key == nil {
+		return false
+	}
+	for i := range kh.key {
+		kh.key[i] = 0
+	}
+	kh.key = nil
+	return true
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyed
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+)
+
+func TestRegister(t *testing.T) {
+	key := []byte("secret")
+	alg, err := Register(HMACSHA256, "test1", key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if alg != "hmac-sha256.test1" || !alg.Available() || alg.Size() != sha256.Size {
+		t.Fatalf("unexpected algorithm: %v", alg)
+	}
+	key[0] = 'S' // the key is copied
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("content"))
+	expected := digest.NewDigestFromEncoded(alg, hex.EncodeToString(mac.Sum(nil)))
+
+	d := alg.FromString("content")
+	if d != expected {
+		t.Fatalf("unexpected digest: %v != %v", d, expected)
+	}
+	if err := d.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if parsed, err := digest.Parse(string(d)); err != nil || parsed != d {
+		t.Fatalf("unexpected parsed digest: %v %v", parsed, err)
+	}
+
+	digester := alg.Digester()
+	digester.Hash().Write([]byte("content"))
+	if digester.Digest() != expected {
+		t.Fatalf("unexpected digest from digester: %v", digester.Digest())
+	}
+
+	verifier := d.Verifier()
+	verifier.Write([]byte("content"))
+	if !verifier.Verified() {
+		t.Fatal("expected content to be verified")
+	}
+	verifier = d.Verifier()
+	verifier.Write([]byte("poisoned"))
+	if verifier.Verified() {
+		t.Fatal("expected poisoned content not to be verified")
+	}
+
+	// the digest is not the plain digest of the content, and depends on the key
+	if d.Encoded() == digest.SHA256.FromString("content").Encoded() {
+		t.Fatal("expected keyed digest to differ from plain digest")
+	}
+	other, err := Register(HMACSHA256, "test2", []byte("other"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other.FromString("content").Encoded() == d.Encoded() {
+		t.Fatal("expected digests with different keys to differ")
+	}
+}
+
+func TestRemove(t *testing.T) {
+	alg, err := Register(HMACSHA512, "test3", []byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := alg.FromString("content")
+
+	if !Remove(alg) {
+		t.Fatal("expected algorithm to be removed")
+	}
+	if Remove(alg) {
+		t.Fatal("expected algorithm to be removed once")
+	}
+	if alg.Available() {
+		t.Fatal("expected removed algorithm not to be available")
+	}
+	if err := d.Validate(); err == nil {
+		t.Fatal("expected digest of removed algorithm not to validate")
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected digesting with a removed key to panic")
+			}
+		}()
+		alg.FromString("content")
+	}()
+
+	if _, err := Register(HMACSHA512, "test3", []byte("secret")); !errors.Is(err, ErrKeyIDInUse) {
+		t.Fatalf("unexpected error reusing key ID: %v", err)
+	}
+	if Remove(digest.SHA256) {
+		t.Fatal("expected only keyed algorithms to be removed")
+	}
+}
+
+func TestRegisterErrors(t *testing.T) {
+	for _, testcase := range []struct {
+		Base  Base
+		KeyID string
+		Key   []byte
+		Err   error
+	}{
+		{Base: "hmac-md5", KeyID: "k", Key: []byte("k"), Err: ErrBaseUnsupported},
+		{Base: HMACSHA256, KeyID: "", Key: []byte("k"), Err: ErrKeyIDInvalid},
+		{Base: HMACSHA256, KeyID: "K1", Key: []byte("k"), Err: ErrKeyIDInvalid},
+		{Base: HMACSHA256, KeyID: "k1.", Key: []byte("k"), Err: ErrKeyIDInvalid},
+		{Base: HMACSHA256, KeyID: "k1", Err: ErrKeyEmpty},
+	} {
+		if _, err := Register(testcase.Base, testcase.KeyID, testcase.Key); !errors.Is(err, testcase.Err) {
+			t.Fatalf("unexpected error registering %q: %v", testcase.KeyID, err)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"hash"
+	"sync"
+)
+
+// parallelWriteSize is the minimum size of a write to a MultiDigester before
+// the hashes are updated concurrently. Smaller writes are cheaper to hash in
+// sequence than to hand off to other goroutines.
+const parallelWriteSize = 256 << 10
+
+// MultiDigester calculates the digests of written data for several algorithms
+// in a single pass. Large writes update the hashes concurrently, but a
+// MultiDigester itself is not safe for concurrent use.
+type MultiDigester struct {
+	algs   []Algorithm
+	hashes []hash.Hash
+}
+
+// NewMultiDigester returns a MultiDigester for the given algorithms. Duplicate
+// algorithms are only calculated once. If no algorithms are given, the
+// Canonical algorithm is used. Like Algorithm.Hash, it panics if any of the
+// algorithms is not available.
+func NewMultiDigester(algs ...Algorithm) *MultiDigester {
+	if len(algs) == 0 {
+		algs = []Algorithm{Canonical}
+	}
+
+	md := &MultiDigester{}
+	for _, alg := range algs {
+		if md.index(alg) >= 0 {
+			continue
+		}
+		md.algs = append(md.algs, alg)
+		md.hashes = append(md.hashes, alg.Hash())
+	}
+	return md
+}
+
+// Write writes p to the hash of every algorithm.
+func (md *MultiDigester) Write(p []byte) (int, error) {
+	if len(md.hashes) == 1 || len(p) < parallelWriteSize {
+		for _, h := range md.hashes {
+			if n, err := h.Write(p); err != nil {
+				return n, err
+			}
+		}
+		return len(p), nil
+	}
+
+	var (
+		wg   sync.WaitGroup
+		errs = make([]error, len(md.hashes))
+	)
+	for i, h := range md.hashes[1:] {
+		wg.Add(1)
+		go func(i int, h hash.Hash) {
+			defer wg.Done()
+			_, errs[i] = h.Write(p)
+		}(i+1, h)
+	}
+	_, errs[0] = md.hashes[0].Write(p)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Algorithms returns the algorithms calculated by the MultiDigester, in the
+// order they were first given to NewMultiDigester.
+func (md *MultiDigester) Algorithms() []Algorithm {
+	return append([]Algorithm(nil), md.algs...)
+}
+
+// Digests returns the current digest for each algorithm, in the same order as
+// Algorithms.
+func (md *MultiDigester) Digests() []Digest {
+	dgsts := make([]Digest, len(md.algs))
+	for i, alg := range md.algs {
+		dgsts[i] = NewDigest(alg, md.hashes[i])
+	}
+	return dgsts
+}
+
+// Digest returns the current digest for alg, or an empty digest if alg is not
+// calculated by the MultiDigester.
+func (md *MultiDigester) Digest(alg Algorithm) Digest {
+	i := md.index(alg)
+	if i < 0 {
+		return ""
+	}
+	return NewDigest(alg, md.hashes[i])
+}
+
+// Reset resets the hashes of all algorithms to their initial state.
+func (md *MultiDigester) Reset() {
+	for _, h := range md.hashes {
+		h.Reset()
+	}
+}
+
+func (md *MultiDigester) index(alg Algorithm) int {
+	for i := range md.algs {
+		if md.algs[i] == alg {
+			return i
+		}
+	}
+	return -1
+}
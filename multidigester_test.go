@@ -0,0 +1,93 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestMultiDigester(t *testing.T) {
+	p := make([]byte, 4*parallelWriteSize+1)
+	if _, err := rand.Read(p); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, testcase := range []struct {
+		Name  string
+		Write func(w io.Writer) error
+	}{
+		{
+			Name: "SmallWrites",
+			Write: func(w io.Writer) error {
+				_, err := io.Copy(w, bytes.NewReader(p))
+				return err
+			},
+		},
+		{
+			Name: "LargeWrite",
+			Write: func(w io.Writer) error {
+				_, err := w.Write(p)
+				return err
+			},
+		},
+	} {
+		t.Run(testcase.Name, func(t *testing.T) {
+			md := NewMultiDigester(SHA256, SHA512, SHA256)
+			if err := testcase.Write(md); err != nil {
+				t.Fatal(err)
+			}
+
+			if algs := md.Algorithms(); !reflect.DeepEqual(algs, []Algorithm{SHA256, SHA512}) {
+				t.Fatalf("unexpected algorithms: %v", algs)
+			}
+			expected := []Digest{SHA256.FromBytes(p), SHA512.FromBytes(p)}
+			if dgsts := md.Digests(); !reflect.DeepEqual(dgsts, expected) {
+				t.Fatalf("unexpected digests: %v != %v", dgsts, expected)
+			}
+			if dgst := md.Digest(SHA512); dgst != expected[1] {
+				t.Fatalf("unexpected digest: %v != %v", dgst, expected[1])
+			}
+			if dgst := md.Digest(SHA384); dgst != "" {
+				t.Fatalf("unexpected digest for algorithm not calculated: %v", dgst)
+			}
+
+			md.Reset()
+			if dgst := md.Digest(SHA256); dgst != SHA256.FromBytes(nil) {
+				t.Fatalf("unexpected digest after reset: %v", dgst)
+			}
+		})
+	}
+}
+
+func TestMultiDigesterDefault(t *testing.T) {
+	if algs := NewMultiDigester().Algorithms(); !reflect.DeepEqual(algs, []Algorithm{Canonical}) {
+		t.Fatalf("unexpected algorithms: %v", algs)
+	}
+}
+
+func BenchmarkMultiDigester(b *testing.B) {
+	p := make([]byte, 1<<20)
+	md := NewMultiDigester(SHA256, SHA512)
+
+	b.SetBytes(int64(len(p)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		md.Write(p)
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"context"
+	"io"
+	"sync"
+)
+
+// pipelineBuffers is the number of buffers used by copyChunksPipelined: one
+// being filled by the reader while the other is digested.
+const pipelineBuffers = 2
+
+// chunk is a buffer filled by the reader goroutine of copyChunksPipelined, or
+// the error that stopped it.
+type chunk struct {
+	buf []byte
+	err error
+}
+
+// copyChunksPipelined behaves like copyChunks, but reads from rd on a separate
+// goroutine, so that reading the next chunk overlaps with writing the current
+// one. It does not return before the reader goroutine has stopped, so rd is
+// not used after it returns.
+func copyChunksPipelined(ctx context.Context, write func([]byte), rd io.Reader, size int) error {
+	var (
+		free = make(chan []byte, pipelineBuffers)
+		full = make(chan chunk, pipelineBuffers)
+		done = make(chan struct{})
+		wg   sync.WaitGroup
+	)
+	for i := 0; i < pipelineBuffers; i++ {
+		free <- make([]byte, size)
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(full)
+
+		for {
+			var buf []byte
+			select {
+			case buf = <-free:
+			case <-done:
+				return
+			}
+
+			n, err := rd.Read(buf)
+			if n > 0 {
+				select {
+				case full <- chunk{buf: buf[:n]}:
+				case <-done:
+					return
+				}
+			} else {
+				free <- buf
+			}
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				select {
+				case full <- chunk{err: err}:
+				case <-done:
+				}
+				return
+			}
+		}
+	}()
+	defer func() {
+		close(done)
+		wg.Wait()
+	}()
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case c, ok := <-full:
+			if !ok {
+				return nil
+			}
+			if c.err != nil {
+				return c.err
+			}
+			write(c.buf)
+			free <- c.buf[:cap(c.buf)]
+		}
+	}
+}
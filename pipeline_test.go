@@ -0,0 +1,146 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+func TestFromReaderContextPipeline(t *testing.T) {
+	p := make([]byte, 1<<20+12345)
+	if _, err := rand.Read(p); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, testcase := range []struct {
+		Name   string
+		Reader func() io.Reader
+	}{
+		{Name: "Full", Reader: func() io.Reader { return bytes.NewReader(p) }},
+		{Name: "Half", Reader: func() io.Reader { return iotest.HalfReader(bytes.NewReader(p)) }},
+		{Name: "DataErr", Reader: func() io.Reader { return iotest.DataErrReader(bytes.NewReader(p)) }},
+	} {
+		t.Run(testcase.Name, func(t *testing.T) {
+			var total int64
+			dgst, err := SHA512.FromReaderContext(context.Background(), testcase.Reader(), ReaderOptions{
+				BufferSize: 10000,
+				Pipeline:   true,
+				Progress: func(p Progress) {
+					total = p.Bytes
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if expected := SHA512.FromBytes(p); dgst != expected {
+				t.Fatalf("unexpected digest: %v != %v", dgst, expected)
+			}
+			if total != int64(len(p)) {
+				t.Fatalf("unexpected progress: %d", total)
+			}
+		})
+	}
+}
+
+func TestFromReaderContextPipelineError(t *testing.T) {
+	expected := errors.New("read failure")
+	rd := io.MultiReader(bytes.NewReader(make([]byte, 1<<20)), iotest.ErrReader(expected))
+
+	_, err := FromReaderContext(context.Background(), rd, ReaderOptions{Pipeline: true})
+	if !errors.Is(err, expected) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestFromReaderContextPipelineCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, err := FromReaderContext(ctx, endlessReader{}, ReaderOptions{
+		Pipeline: true,
+		Progress: func(p Progress) {
+			if p.Bytes >= 1<<20 {
+				cancel()
+			}
+		},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// slowReader simulates a reader with a fixed latency for every read, such as
+// a disk or network.
+type slowReader struct {
+	latency   time.Duration
+	remaining int
+}
+
+func (r *slowReader) Read(p []byte) (int, error) {
+	if r.remaining == 0 {
+		return 0, io.EOF
+	}
+	time.Sleep(r.latency)
+	if len(p) > r.remaining {
+		p = p[:r.remaining]
+	}
+	r.remaining -= len(p)
+	return len(p), nil
+}
+
+func benchmarkFromReaderContextSlow(b *testing.B, pipeline bool) {
+	const (
+		size      = 64 << 20
+		chunkSize = 1 << 20
+	)
+
+	// Use a latency close to the time needed to hash a chunk, so that the
+	// pipeline can hide up to half of the total time. Chunks are large
+	// enough for the latency to be well above the resolution of time.Sleep.
+	chunk := make([]byte, chunkSize)
+	start := time.Now()
+	for i := 0; i < 16; i++ {
+		SHA256.FromBytes(chunk)
+	}
+	latency := time.Since(start) / 16
+
+	b.SetBytes(size)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rd := &slowReader{latency: latency, remaining: size}
+		if _, err := FromReaderContext(context.Background(), rd, ReaderOptions{BufferSize: chunkSize, Pipeline: pipeline}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkFromReaderContextSlow compares digesting a slow reader with and
+// without the pipeline. Run with -cpu 2 or more: the pipeline approaches the
+// larger of the read and hash times, rather than their sum.
+func BenchmarkFromReaderContextSlow(b *testing.B) {
+	b.Run("Serial", func(b *testing.B) {
+		benchmarkFromReaderContextSlow(b, false)
+	})
+	b.Run("Pipeline", func(b *testing.B) {
+		benchmarkFromReaderContextSlow(b, true)
+	})
+}
@@ -0,0 +1,151 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"bytes"
+	"io"
+	"os"
+)
+
+// QuarantineOptions configures a QuarantineReader. The zero value is ready to
+// use.
+type QuarantineOptions struct {
+	// MemoryLimit is the amount of content buffered in memory. Content beyond
+	// it is buffered in a temporary file. If zero, 16 MiB are used.
+	MemoryLimit int64
+
+	// TempDir is the directory of the temporary file. If empty, the default
+	// directory for temporary files is used.
+	TempDir string
+}
+
+// QuarantineReader withholds content until it is verified. The first call to
+// Read or Verify reads the underlying reader to io.EOF, buffering the content,
+// and only once the content matches the expected digest is it released to the
+// reader. On mismatch, the content is discarded and Read returns a
+// *MismatchError, so consumers such as decompressors or parsers never act on
+// unverified content.
+//
+// Close must be called to remove the temporary file, if any. It is removed
+// right away when verification fails.
+type QuarantineReader struct {
+	r      io.Reader
+	digest Digest
+	opts   QuarantineOptions
+
+	filled  bool
+	err     error // verification error, once filled
+	mem     []byte
+	spill   *os.File
+	content io.Reader // verified content, once filled
+	closed  bool
+}
+
+// NewQuarantineReader returns a QuarantineReader for the content of r, which
+// is expected to match d. Like Digest.Verifier, it panics if the algorithm of
+// d is not available.
+func NewQuarantineReader(r io.Reader, d Digest, opts QuarantineOptions) *QuarantineReader {
+	d.Algorithm().Hash() // panic early, like Digest.Verifier
+	if opts.MemoryLimit == 0 {
+		opts.MemoryLimit = defaultMemoryLimit
+	}
+	return &QuarantineReader{
+		r:      r,
+		digest: d,
+		opts:   opts,
+	}
+}
+
+// Verify reads and buffers the content, if not done yet, and returns nil if it
+// matches the expected digest. Errors reading the content are returned as is,
+// mismatches as a *MismatchError.
+func (q *QuarantineReader) Verify() error {
+	if q.closed {
+		return os.ErrClosed
+	}
+	if !q.filled {
+		q.filled = true
+		if q.err = q.fill(); q.err != nil {
+			q.release()
+		}
+	}
+	return q.err
+}
+
+// Read reads verified content. It returns the error of Verify, if any.
+func (q *QuarantineReader) Read(p []byte) (int, error) {
+	if err := q.Verify(); err != nil {
+		return 0, err
+	}
+	return q.content.Read(p)
+}
+
+// Close discards the content and removes the temporary file, if any.
+func (q *QuarantineReader) Close() error {
+	if q.closed {
+		return nil
+	}
+	q.closed = true
+	return q.release()
+}
+
+// fill reads the content, holding the first MemoryLimit bytes in memory and
+// the remainder in the temporary file.
+func (q *QuarantineReader) fill() error {
+	vr := VerifyReader(q.r, q.digest)
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, io.LimitReader(vr, q.opts.MemoryLimit)); err != nil {
+		return err
+	}
+	q.mem = buf.Bytes()
+	q.content = bytes.NewReader(q.mem)
+	if int64(len(q.mem)) < q.opts.MemoryLimit {
+		return nil
+	}
+
+	// the content may continue beyond the memory limit
+	f, err := os.CreateTemp(q.opts.TempDir, "digest-quarantine-")
+	if err != nil {
+		return err
+	}
+	q.spill = f
+	if _, err := io.Copy(f, vr); err != nil {
+		return err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	q.content = io.MultiReader(q.content, f)
+	return nil
+}
+
+// release discards the buffered content.
+func (q *QuarantineReader) release() error {
+	q.mem = nil
+	q.content = nil
+	if q.spill == nil {
+		return nil
+	}
+
+	f := q.spill
+	q.spill = nil
+	err := f.Close()
+	if rerr := os.Remove(f.Name()); err == nil {
+		err = rerr
+	}
+	return err
+}
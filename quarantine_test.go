@@ -0,0 +1,119 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"io"
+	"os"
+	"testing"
+	"testing/iotest"
+)
+
+func TestQuarantineReader(t *testing.T) {
+	p := make([]byte, 100<<10)
+	if _, err := rand.Read(p); err != nil {
+		t.Fatal(err)
+	}
+	d := FromBytes(p)
+
+	for _, testcase := range []struct {
+		Name        string
+		MemoryLimit int64
+		Spilled     bool
+	}{
+		{Name: "Memory"},
+		{Name: "Spill", MemoryLimit: 10 << 10, Spilled: true},
+		{Name: "Exact", MemoryLimit: int64(len(p)), Spilled: true},
+	} {
+		t.Run(testcase.Name, func(t *testing.T) {
+			dir := t.TempDir()
+			q := NewQuarantineReader(bytes.NewReader(p), d, QuarantineOptions{
+				MemoryLimit: testcase.MemoryLimit,
+				TempDir:     dir,
+			})
+
+			if err := q.Verify(); err != nil {
+				t.Fatal(err)
+			}
+			if entries, _ := os.ReadDir(dir); (len(entries) > 0) != testcase.Spilled {
+				t.Fatalf("unexpected temporary files: %v", entries)
+			}
+
+			content, err := io.ReadAll(q)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(content, p) {
+				t.Fatal("unexpected content")
+			}
+
+			if err := q.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+				t.Fatalf("expected temporary files to be removed: %v", entries)
+			}
+			if _, err := q.Read(make([]byte, 1)); !errors.Is(err, os.ErrClosed) {
+				t.Fatalf("unexpected error after close: %v", err)
+			}
+		})
+	}
+}
+
+func TestQuarantineReaderFailure(t *testing.T) {
+	p := bytes.Repeat([]byte("quarantine"), 10<<10)
+	readErr := errors.New("read failed")
+
+	for _, testcase := range []struct {
+		Name   string
+		Reader io.Reader
+		Err    error
+	}{
+		{
+			Name:   "Mismatch",
+			Reader: bytes.NewReader(p[1:]),
+			Err:    ErrDigestMismatch,
+		},
+		{
+			Name:   "ReadError",
+			Reader: io.MultiReader(bytes.NewReader(p[:50<<10]), iotest.ErrReader(readErr)),
+			Err:    readErr,
+		},
+	} {
+		t.Run(testcase.Name, func(t *testing.T) {
+			dir := t.TempDir()
+			q := NewQuarantineReader(testcase.Reader, FromBytes(p), QuarantineOptions{
+				MemoryLimit: 1 << 10,
+				TempDir:     dir,
+			})
+			defer q.Close()
+
+			// no content is released before the failure
+			n, err := q.Read(make([]byte, 1<<10))
+			if n != 0 || !errors.Is(err, testcase.Err) {
+				t.Fatalf("unexpected read: %d %v", n, err)
+			}
+			if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+				t.Fatalf("expected temporary files to be removed on failure: %v", entries)
+			}
+			if err := q.Verify(); !errors.Is(err, testcase.Err) {
+				t.Fatalf("expected error to be retained: %v", err)
+			}
+		})
+	}
+}
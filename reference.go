@@ -0,0 +1,57 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var (
+	// ErrReferenceMissingDigest returned when a reference has no '@' separator.
+	ErrReferenceMissingDigest = errors.New("missing '@' separator in digest reference")
+
+	// ErrReferenceMultipleDigests returned when a reference has more than one
+	// '@' separator.
+	ErrReferenceMultipleDigests = errors.New("multiple '@' separators in digest reference")
+)
+
+// SplitReference splits a digest-pinned string of the form "name@digest",
+// such as "registry/repo@sha256:…" or "file.tar@sha256:…", into its name and
+// digest. The name is returned as is, without interpretation, and may be
+// empty. The digest is validated as with Parse; if that fails, the name and
+// the unvalidated digest are returned along with the error.
+func SplitReference(s string) (name string, d Digest, err error) {
+	name, dgst, ok := strings.Cut(s, "@")
+	if !ok {
+		return "", "", ErrReferenceMissingDigest
+	}
+	if strings.Contains(dgst, "@") {
+		return "", "", ErrReferenceMultipleDigests
+	}
+	d, err = Parse(dgst)
+	return name, d, err
+}
+
+// ReferenceRegexp returns an anchored regular expression matching references
+// of the form "name@digest", where name is matched by the given expression
+// and the digest by DigestRegexp. The name and digest are captured by the
+// subexpressions named "name" and "digest".
+//
+// The digest is only matched syntactically; use Parse to validate it.
+func ReferenceRegexp(name *regexp.Regexp) *regexp.Regexp {
+	return regexp.MustCompile(`^(?P<name>` + name.String() + `)@(?P<digest>` + DigestRegexp.String() + `)$`)
+}
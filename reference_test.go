@@ -0,0 +1,124 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+)
+
+func TestSplitReference(t *testing.T) {
+	const dgst = "sha256:e58fcf7418d4390dec8e8fb69d88c06ec07039d651fedd3aa72af9972e7d046b"
+
+	for _, tc := range []struct {
+		Input  string
+		Name   string
+		Digest digest.Digest
+		Err    error
+	}{
+		{
+			Input:  "registry.example.com:5000/repo@" + dgst,
+			Name:   "registry.example.com:5000/repo",
+			Digest: dgst,
+		},
+		{
+			Input:  "file.tar@" + dgst,
+			Name:   "file.tar",
+			Digest: dgst,
+		},
+		{
+			// name is not interpreted
+			Input:  "  Not A Name!@" + dgst,
+			Name:   "  Not A Name!",
+			Digest: dgst,
+		},
+		{
+			Input:  "@" + dgst,
+			Name:   "",
+			Digest: dgst,
+		},
+		{
+			Input: "file.tar",
+			Err:   digest.ErrReferenceMissingDigest,
+		},
+		{
+			Input: dgst,
+			Err:   digest.ErrReferenceMissingDigest,
+		},
+		{
+			Input: "user@host/repo@" + dgst,
+			Err:   digest.ErrReferenceMultipleDigests,
+		},
+		{
+			Input: "repo@" + dgst + "@" + dgst,
+			Err:   digest.ErrReferenceMultipleDigests,
+		},
+		{
+			Input:  "repo@sha256:abcdef",
+			Name:   "repo",
+			Digest: "sha256:abcdef",
+			Err:    digest.ErrDigestInvalidLength,
+		},
+		{
+			Input: "repo@",
+			Name:  "repo",
+			Err:   digest.ErrDigestInvalidFormat,
+		},
+	} {
+		t.Run(tc.Input, func(t *testing.T) {
+			name, d, err := digest.SplitReference(tc.Input)
+			if !errors.Is(err, tc.Err) {
+				t.Fatalf("unexpected error splitting %q: %v != %v", tc.Input, err, tc.Err)
+			}
+			if name != tc.Name {
+				t.Fatalf("unexpected name: %q != %q", name, tc.Name)
+			}
+			if d != tc.Digest {
+				t.Fatalf("unexpected digest: %q != %q", d, tc.Digest)
+			}
+		})
+	}
+}
+
+func TestReferenceRegexp(t *testing.T) {
+	const dgst = "sha256:e58fcf7418d4390dec8e8fb69d88c06ec07039d651fedd3aa72af9972e7d046b"
+
+	re := digest.ReferenceRegexp(regexp.MustCompile(`[a-z0-9]+(?:/[a-z0-9]+)*`))
+
+	m := re.FindStringSubmatch("library/busybox@" + dgst)
+	if m == nil {
+		t.Fatal("expected reference to match")
+	}
+	if name := m[re.SubexpIndex("name")]; name != "library/busybox" {
+		t.Fatalf("unexpected name: %q", name)
+	}
+	if d := m[re.SubexpIndex("digest")]; d != dgst {
+		t.Fatalf("unexpected digest: %q", d)
+	}
+
+	for _, s := range []string{
+		"library/busybox",
+		"Library/busybox@" + dgst,
+		"library/busybox@" + dgst + "@" + dgst,
+		"library/busybox@" + dgst + " ",
+	} {
+		if re.MatchString(s) {
+			t.Fatalf("unexpected match for %q", s)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrDigesterNotResumable returned when the hash of an algorithm cannot
+	// save and restore its state.
+	ErrDigesterNotResumable = errors.New("digest algorithm does not support resumable state")
+
+	// ErrDigesterStateInvalid returned when a digester state cannot be decoded.
+	ErrDigesterStateInvalid = errors.New("invalid digester state")
+)
+
+// digesterStateVersion is the version of the format written by
+// ResumableDigester.MarshalBinary.
+const digesterStateVersion = 1
+
+// ResumableDigester is a CountingDigester whose state can be saved with
+// MarshalBinary and restored, possibly in another process, with
+// RestoreDigester.
+//
+// The state is encoded as a version byte, the uvarint-prefixed algorithm
+// name, the uvarint byte count and the state of the hash, as returned by its
+// MarshalBinary method. The hash state is only portable between binaries using
+// the same hash implementation.
+type ResumableDigester interface {
+	CountingDigester
+	encoding.BinaryMarshaler
+}
+
+type resumableDigester struct {
+	*countingDigester
+}
+
+// ResumableDigester returns a new digester for the specified algorithm whose
+// state can be saved and restored. The hash of the algorithm must implement
+// encoding.BinaryMarshaler and encoding.BinaryUnmarshaler, as the SHA-2
+// hashes of the standard library do; ErrDigesterNotResumable is returned
+// otherwise. Like Hash, it panics if the algorithm is not available.
+func (a Algorithm) ResumableDigester() (ResumableDigester, error) {
+	h := a.Hash()
+	if !isResumable(h) {
+		return nil, fmt.Errorf("%w: %s", ErrDigesterNotResumable, a)
+	}
+	return resumableDigester{
+		countingDigester: &countingDigester{
+			alg:  a,
+			hash: &countingHash{Hash: h},
+		},
+	}, nil
+}
+
+// RestoreDigester returns a digester restored from a state saved by
+// ResumableDigester.MarshalBinary. Writing the remaining content to the
+// restored digester yields the same digest as writing all of it to the
+// original one.
+func RestoreDigester(state []byte) (ResumableDigester, error) {
+	if len(state) == 0 || state[0] != digesterStateVersion {
+		return nil, ErrDigesterStateInvalid
+	}
+	p := state[1:]
+
+	l, n := binary.Uvarint(p)
+	if n <= 0 || l > uint64(len(p)-n) {
+		return nil, ErrDigesterStateInvalid
+	}
+	alg := Algorithm(p[n : n+int(l)])
+	p = p[n+int(l):]
+
+	size, n := binary.Uvarint(p)
+	if n <= 0 || size > 1<<63-1 {
+		return nil, ErrDigesterStateInvalid
+	}
+	p = p[n:]
+
+	if !alg.Available() {
+		return nil, fmt.Errorf("%w: %s", ErrDigestUnsupported, alg)
+	}
+	d, err := alg.ResumableDigester()
+	if err != nil {
+		return nil, err
+	}
+	h := d.(resumableDigester).hash
+	if err := h.Hash.(encoding.BinaryUnmarshaler).UnmarshalBinary(p); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDigesterStateInvalid, err)
+	}
+	h.n = int64(size)
+	return d, nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (d resumableDigester) MarshalBinary() ([]byte, error) {
+	state, err := d.hash.Hash.(encoding.BinaryMarshaler).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf [binary.MaxVarintLen64]byte
+	p := make([]byte, 0, 1+2*len(buf)+len(d.alg)+len(state))
+	p = append(p, digesterStateVersion)
+	p = append(p, buf[:binary.PutUvarint(buf[:], uint64(len(d.alg)))]...)
+	p = append(p, d.alg...)
+	p = append(p, buf[:binary.PutUvarint(buf[:], uint64(d.hash.n))]...)
+	return append(p, state...), nil
+}
+
+func isResumable(h interface{}) bool {
+	_, ok := h.(encoding.BinaryMarshaler)
+	if !ok {
+		return false
+	}
+	_, ok = h.(encoding.BinaryUnmarshaler)
+	return ok
+}
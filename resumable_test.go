@@ -0,0 +1,106 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"crypto"
+	"crypto/rand"
+	"errors"
+	"hash"
+	"testing"
+)
+
+func TestResumableDigester(t *testing.T) {
+	p := make([]byte, 1<<20)
+	if _, err := rand.Read(p); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, alg := range []Algorithm{SHA256, SHA512} {
+		t.Run(string(alg), func(t *testing.T) {
+			d, err := alg.ResumableDigester()
+			if err != nil {
+				t.Fatal(err)
+			}
+			d.Hash().Write(p[:12345])
+
+			state, err := d.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			restored, err := RestoreDigester(state)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if restored.Size() != 12345 {
+				t.Fatalf("unexpected size of restored digester: %d", restored.Size())
+			}
+			restored.Hash().Write(p[12345:])
+
+			expected := Descriptor{Digest: alg.FromBytes(p), Size: int64(len(p))}
+			if desc := restored.Descriptor(); desc != expected {
+				t.Fatalf("unexpected descriptor: %+v != %+v", desc, expected)
+			}
+		})
+	}
+}
+
+func TestResumableDigesterUnsupported(t *testing.T) {
+	const alg = Algorithm("test-not-resumable")
+	RegisterAlgorithm(alg, notResumable{})
+
+	if _, err := alg.ResumableDigester(); !errors.Is(err, ErrDigesterNotResumable) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestRestoreDigesterInvalid(t *testing.T) {
+	d, err := SHA256.ResumableDigester()
+	if err != nil {
+		t.Fatal(err)
+	}
+	state, err := d.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, testcase := range []struct {
+		Name  string
+		State []byte
+		Err   error
+	}{
+		{Name: "Empty", Err: ErrDigesterStateInvalid},
+		{Name: "Version", State: append([]byte{0}, state[1:]...), Err: ErrDigesterStateInvalid},
+		{Name: "Truncated", State: state[:len(state)-1], Err: ErrDigesterStateInvalid},
+		{Name: "AlgorithmLength", State: []byte{digesterStateVersion, 0xff, 0x01}, Err: ErrDigesterStateInvalid},
+		{Name: "Unsupported", State: []byte{digesterStateVersion, 4, 'b', 'e', 'a', 'n', 0}, Err: ErrDigestUnsupported},
+	} {
+		t.Run(testcase.Name, func(t *testing.T) {
+			if _, err := RestoreDigester(testcase.State); !errors.Is(err, testcase.Err) {
+				t.Fatalf("unexpected error: %v != %v", err, testcase.Err)
+			}
+		})
+	}
+}
+
+// notResumable is a CryptoHash whose hashes cannot save their state.
+type notResumable struct{}
+
+func (notResumable) Available() bool { return true }
+func (notResumable) Size() int       { return crypto.SHA256.Size() }
+func (notResumable) New() hash.Hash {
+	return struct{ hash.Hash }{crypto.SHA256.New()}
+}
@@ -0,0 +1,248 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"runtime"
+)
+
+// ErrSymlink returned by DigestTree for symbolic links when using SymlinkError.
+var ErrSymlink = errors.New("symbolic link not allowed")
+
+// SymlinkPolicy selects how DigestTree handles symbolic links.
+type SymlinkPolicy int
+
+const (
+	// SymlinkSkip ignores symbolic links. It is the default.
+	SymlinkSkip SymlinkPolicy = iota
+
+	// SymlinkFollow digests the target of symbolic links to regular files.
+	// Links to directories are not traversed.
+	SymlinkFollow
+
+	// SymlinkError reports symbolic links as results with ErrSymlink.
+	SymlinkError
+)
+
+// TreeOptions configures DigestTree. The zero value is ready to use.
+type TreeOptions struct {
+	// Algorithms are the algorithms to digest files with. If empty, the
+	// Canonical algorithm is used.
+	Algorithms []Algorithm
+
+	// Include, if not empty, restricts the files digested to those matching
+	// at least one of the patterns.
+	Include []string
+
+	// Exclude skips files and directories matching any of the patterns.
+	Exclude []string
+
+	// Symlinks selects how symbolic links are handled.
+	Symlinks SymlinkPolicy
+
+	// Workers is the number of files digested concurrently. If zero,
+	// GOMAXPROCS is used.
+	Workers int
+}
+
+// TreeResult is the result of digesting a file with DigestTree.
+type TreeResult struct {
+	// Path is the path of the file in the file system.
+	Path string
+
+	// Descriptor holds the size of the file and its digest with the first
+	// algorithm.
+	Descriptor Descriptor
+
+	// Digests holds the digests of the file, one for each distinct algorithm
+	// in the order given.
+	Digests []Digest
+
+	// Err is set if the file, or the directory with the given path, could not
+	// be digested. Other fields may be unset.
+	Err error
+}
+
+// DigestTree walks the file tree rooted at root in fsys, and digests every
+// regular file on a bounded pool of workers. Results are sent to the returned
+// channel in lexical order, as walked by fs.WalkDir, regardless of the order
+// in which the workers finish.
+//
+// Include and Exclude patterns use the syntax of path.Match, and match either
+// the full path of a file or its base name. An error is returned if a pattern
+// is malformed or an algorithm is not available.
+//
+// The channel is closed once all results have been sent, or when ctx is done.
+// Callers must either receive until the channel is closed or cancel ctx.
+func DigestTree(ctx context.Context, fsys fs.FS, root string, opts TreeOptions) (<-chan TreeResult, error) {
+	algs := opts.Algorithms
+	if len(algs) == 0 {
+		algs = []Algorithm{Canonical}
+	}
+	for _, alg := range algs {
+		if !alg.Available() {
+			return nil, fmt.Errorf("%w: %s", ErrDigestUnsupported, alg)
+		}
+	}
+	for _, pattern := range append(append([]string(nil), opts.Include...), opts.Exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("%w: %q", err, pattern)
+		}
+	}
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	type job struct {
+		path   string
+		result chan<- TreeResult
+	}
+
+	var (
+		jobs    = make(chan job, workers)
+		pending = make(chan chan TreeResult, 2*workers) // in walk order
+		out     = make(chan TreeResult, workers)
+	)
+
+	// enqueue reserves a slot for a result in walk order. It returns nil if
+	// ctx is done.
+	enqueue := func() chan TreeResult {
+		result := make(chan TreeResult, 1)
+		select {
+		case pending <- result:
+			return result
+		case <-ctx.Done():
+			return nil
+		}
+	}
+
+	go func() {
+		defer close(jobs)
+		defer close(pending)
+
+		fs.WalkDir(fsys, root, func(p string, d fs.DirEntry, err error) error {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if err != nil {
+				if result := enqueue(); result != nil {
+					result <- TreeResult{Path: p, Err: err}
+				}
+				return nil
+			}
+			if matchAny(opts.Exclude, p) {
+				if d.IsDir() {
+					return fs.SkipDir
+				}
+				return nil
+			}
+			if d.IsDir() || (len(opts.Include) > 0 && !matchAny(opts.Include, p)) {
+				return nil
+			}
+
+			switch {
+			case d.Type().IsRegular():
+			case d.Type()&fs.ModeSymlink != 0:
+				switch opts.Symlinks {
+				case SymlinkFollow:
+					fi, err := fs.Stat(fsys, p)
+					if err == nil && !fi.Mode().IsRegular() {
+						return nil
+					}
+				case SymlinkError:
+					if result := enqueue(); result != nil {
+						result <- TreeResult{Path: p, Err: &fs.PathError{Op: "digest", Path: p, Err: ErrSymlink}}
+					}
+					return nil
+				default:
+					return nil
+				}
+			default:
+				return nil
+			}
+
+			if result := enqueue(); result != nil {
+				jobs <- job{path: p, result: result}
+			}
+			return nil
+		})
+	}()
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			for j := range jobs {
+				j.result <- digestTreeFile(fsys, j.path, algs)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(out)
+
+		for result := range pending {
+			select {
+			case out <- <-result:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// digestTreeFile digests the file at p with each of the algorithms.
+func digestTreeFile(fsys fs.FS, p string, algs []Algorithm) TreeResult {
+	f, err := fsys.Open(p)
+	if err != nil {
+		return TreeResult{Path: p, Err: err}
+	}
+	defer f.Close()
+
+	md := NewMultiDigester(algs...)
+	n, err := io.Copy(md, f)
+	if err != nil {
+		return TreeResult{Path: p, Err: err}
+	}
+
+	dgsts := md.Digests()
+	return TreeResult{
+		Path:       p,
+		Descriptor: Descriptor{Digest: dgsts[0], Size: n},
+		Digests:    dgsts,
+	}
+}
+
+// matchAny reports whether any of the patterns matches p or its base name.
+// Patterns are validated by DigestTree.
+func matchAny(patterns []string, p string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, p); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, path.Base(p)); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,155 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func collectTree(t *testing.T, fsys fs.FS, root string, opts TreeOptions) []TreeResult {
+	t.Helper()
+	ch, err := DigestTree(context.Background(), fsys, root, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var results []TreeResult
+	for result := range ch {
+		results = append(results, result)
+	}
+	return results
+}
+
+func TestDigestTree(t *testing.T) {
+	fsys := fstest.MapFS{}
+	var expected []TreeResult
+	for i := 0; i < 100; i++ {
+		p := fmt.Sprintf("dir%d/file%02d.json", i%3, i)
+		fsys[p] = &fstest.MapFile{Data: []byte(p)}
+	}
+	fsys["dir1/skip/file.json"] = &fstest.MapFile{Data: []byte("skipped")}
+	fsys["dir2/file.txt"] = &fstest.MapFile{Data: []byte("not included")}
+	fsys["dir2/file.json~"] = &fstest.MapFile{Data: []byte("excluded")}
+
+	paths, err := fs.Glob(fsys, "dir*/file*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range paths {
+		content := fsys[p].Data
+		expected = append(expected, TreeResult{
+			Path:       p,
+			Descriptor: Descriptor{Digest: SHA256.FromBytes(content), Size: int64(len(content))},
+			Digests:    []Digest{SHA256.FromBytes(content), SHA512.FromBytes(content)},
+		})
+	}
+
+	results := collectTree(t, fsys, ".", TreeOptions{
+		Algorithms: []Algorithm{SHA256, SHA512},
+		Include:    []string{"*.json"},
+		Exclude:    []string{"skip", "*~"},
+		Workers:    4,
+	})
+	if !reflect.DeepEqual(results, expected) {
+		t.Fatalf("unexpected results:\n%+v\nexpected:\n%+v", results, expected)
+	}
+}
+
+func TestDigestTreeSymlinks(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "file"), []byte("hello"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("file", filepath.Join(dir, "link")); err != nil {
+		t.Skipf("symbolic links not supported: %v", err)
+	}
+	if err := os.Symlink(".", filepath.Join(dir, "loop")); err != nil {
+		t.Fatal(err)
+	}
+	fsys := os.DirFS(dir)
+	file := TreeResult{Path: "file", Descriptor: Descriptor{Digest: FromString("hello"), Size: 5}, Digests: []Digest{FromString("hello")}}
+
+	for _, testcase := range []struct {
+		Policy   SymlinkPolicy
+		Expected []TreeResult
+	}{
+		{
+			Policy:   SymlinkSkip,
+			Expected: []TreeResult{file},
+		},
+		{
+			Policy: SymlinkFollow,
+			Expected: []TreeResult{file, {
+				Path:       "link",
+				Descriptor: file.Descriptor,
+				Digests:    file.Digests,
+			}},
+		},
+	} {
+		results := collectTree(t, fsys, ".", TreeOptions{Symlinks: testcase.Policy})
+		if !reflect.DeepEqual(results, testcase.Expected) {
+			t.Fatalf("unexpected results for policy %d: %+v", testcase.Policy, results)
+		}
+	}
+
+	results := collectTree(t, fsys, ".", TreeOptions{Symlinks: SymlinkError})
+	if len(results) != 3 || !errors.Is(results[1].Err, ErrSymlink) || !errors.Is(results[2].Err, ErrSymlink) {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+}
+
+func TestDigestTreeErrors(t *testing.T) {
+	if _, err := DigestTree(context.Background(), fstest.MapFS{}, ".", TreeOptions{Include: []string{"["}}); err == nil {
+		t.Fatal("expected error for malformed pattern")
+	}
+	if _, err := DigestTree(context.Background(), fstest.MapFS{}, ".", TreeOptions{Algorithms: []Algorithm{"bean"}}); !errors.Is(err, ErrDigestUnsupported) {
+		t.Fatalf("unexpected error for unsupported algorithm: %v", err)
+	}
+
+	results := collectTree(t, fstest.MapFS{}, "missing", TreeOptions{})
+	if len(results) != 1 || !errors.Is(results[0].Err, os.ErrNotExist) {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+}
+
+func TestDigestTreeCancel(t *testing.T) {
+	fsys := fstest.MapFS{}
+	for i := 0; i < 1000; i++ {
+		fsys[fmt.Sprintf("file%04d", i)] = &fstest.MapFile{Data: []byte("content")}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := DigestTree(ctx, fsys, ".", TreeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-ch
+	cancel()
+
+	n := 1
+	for range ch {
+		n++
+	}
+	if n == len(fsys) {
+		t.Fatal("expected cancellation to stop the walk")
+	}
+}
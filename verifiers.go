@@ -16,6 +16,7 @@
 package digest
 
 import (
+	"fmt"
 	"hash"
 	"io"
 )
@@ -44,3 +45,195 @@ func (hv hashVerifier) Write(p []byte) (n int, err error) {
 func (hv hashVerifier) Verified() bool {
 	return hv.digest == NewDigest(hv.digest.Algorithm(), hv.hash)
 }
+
+// DescriptorVerifier is a Verifier that also enforces the size of the content.
+// Writes fail as soon as the content exceeds the expected size, so that
+// oversized content can be rejected without consuming it entirely.
+type DescriptorVerifier interface {
+	Verifier
+
+	// Verify returns nil if the content written matches the expected digest
+	// and size, or else a *MismatchError.
+	Verify() error
+}
+
+// MismatchError describes content that does not match the expected digest or
+// size. It matches ErrDigestMismatch and ErrSizeMismatch with errors.Is,
+// depending on what does not match.
+type MismatchError struct {
+	// ExpectedDigest and ExpectedSize are the expected digest and size of the
+	// content. If only the digest is expected, ExpectedSize is ActualSize.
+	ExpectedDigest Digest
+	ExpectedSize   int64
+
+	// ActualDigest is the digest of the content. It is empty if the content
+	// was rejected for exceeding the expected size.
+	ActualDigest Digest
+
+	// ActualSize is the size of the content, or of the content up to the
+	// write that exceeded the expected size.
+	ActualSize int64
+}
+
+func (e *MismatchError) Error() string {
+	switch {
+	case e.ActualDigest == "":
+		return fmt.Sprintf("%v: expected %d bytes, got at least %d", ErrSizeMismatch, e.ExpectedSize, e.ActualSize)
+	case e.ActualSize != e.ExpectedSize:
+		return fmt.Sprintf("%v: expected %d bytes, got %d", ErrSizeMismatch, e.ExpectedSize, e.ActualSize)
+	default:
+		return fmt.Sprintf("%v: expected %s, got %s", ErrDigestMismatch, e.ExpectedDigest, e.ActualDigest)
+	}
+}
+
+// Is reports whether target is ErrSizeMismatch and the sizes differ, or
+// ErrDigestMismatch and the digests differ.
+func (e *MismatchError) Is(target error) bool {
+	switch target {
+	case ErrSizeMismatch:
+		return e.ActualSize != e.ExpectedSize
+	case ErrDigestMismatch:
+		return e.ActualDigest != e.ExpectedDigest
+	}
+	return false
+}
+
+// NewDescriptorVerifier returns a DescriptorVerifier for content with the
+// digest d and size bytes. Like Digest.Verifier, it panics if the algorithm
+// of d is not available.
+func NewDescriptorVerifier(d Digest, size int64) DescriptorVerifier {
+	return newDescriptorVerifier(d, size, d.Algorithm().Hash())
+}
+
+func newDescriptorVerifier(d Digest, size int64, h hash.Hash) *descriptorVerifier {
+	return &descriptorVerifier{
+		digest: d,
+		size:   size,
+		hash:   h,
+	}
+}
+
+type descriptorVerifier struct {
+	digest Digest
+	size   int64
+	hash   hash.Hash
+	n      int64
+	err    error // set once the size is exceeded
+}
+
+func (dv *descriptorVerifier) Write(p []byte) (int, error) {
+	if dv.err != nil {
+		return 0, dv.err
+	}
+	if int64(len(p)) > dv.size-dv.n {
+		dv.err = &MismatchError{
+			ExpectedDigest: dv.digest,
+			ExpectedSize:   dv.size,
+			ActualSize:     dv.n + int64(len(p)),
+		}
+		return 0, dv.err
+	}
+	dv.hash.Write(p)
+	dv.n += int64(len(p))
+	return len(p), nil
+}
+
+func (dv *descriptorVerifier) Verify() error {
+	if dv.err != nil {
+		return dv.err
+	}
+	actual := NewDigest(dv.digest.Algorithm(), dv.hash)
+	if dv.n != dv.size || actual != dv.digest {
+		return &MismatchError{
+			ExpectedDigest: dv.digest,
+			ExpectedSize:   dv.size,
+			ActualDigest:   actual,
+			ActualSize:     dv.n,
+		}
+	}
+	return nil
+}
+
+func (dv *descriptorVerifier) Verified() bool {
+	return dv.Verify() == nil
+}
+
+// MultiVerifier is a Verifier for several digests of the same content, such as
+// digests with different algorithms during a migration.
+type MultiVerifier interface {
+	Verifier
+
+	// Matched returns the digests matched by the content written, in the order
+	// they were given.
+	Matched() []Digest
+}
+
+// NewAnyVerifier returns a MultiVerifier whose Verified method reports whether
+// the content matches any of the digests. The content is hashed once for each
+// distinct algorithm. Like Digest.Verifier, it panics if the algorithm of any
+// of the digests is not available.
+func NewAnyVerifier(ds ...Digest) MultiVerifier {
+	return newMultiVerifier(ds, false)
+}
+
+// NewAllVerifier returns a MultiVerifier like NewAnyVerifier, whose Verified
+// method reports whether the content matches all of the digests.
+func NewAllVerifier(ds ...Digest) MultiVerifier {
+	return newMultiVerifier(ds, true)
+}
+
+func newMultiVerifier(ds []Digest, all bool) *multiVerifier {
+	mv := &multiVerifier{all: all}
+	var algs []Algorithm
+	for _, d := range ds {
+		if mv.contains(d) {
+			continue
+		}
+		mv.digests = append(mv.digests, d)
+		algs = append(algs, d.Algorithm())
+	}
+	if len(algs) > 0 {
+		mv.md = NewMultiDigester(algs...)
+	}
+	return mv
+}
+
+type multiVerifier struct {
+	digests []Digest
+	md      *MultiDigester // nil without digests
+	all     bool
+}
+
+func (mv *multiVerifier) Write(p []byte) (int, error) {
+	if mv.md == nil {
+		return len(p), nil
+	}
+	return mv.md.Write(p)
+}
+
+func (mv *multiVerifier) Matched() []Digest {
+	var matched []Digest
+	for _, d := range mv.digests {
+		if mv.md.Digest(d.Algorithm()) == d {
+			matched = append(matched, d)
+		}
+	}
+	return matched
+}
+
+func (mv *multiVerifier) Verified() bool {
+	matched := mv.Matched()
+	if mv.all {
+		return len(mv.digests) > 0 && len(matched) == len(mv.digests)
+	}
+	return len(matched) > 0
+}
+
+func (mv *multiVerifier) contains(d Digest) bool {
+	for _, other := range mv.digests {
+		if other == d {
+			return true
+		}
+	}
+	return false
+}
@@ -18,6 +18,7 @@ package digest
 import (
 	"bytes"
 	"crypto/rand"
+	"errors"
 	"io"
 	"reflect"
 	"testing"
@@ -79,3 +80,141 @@ func TestVerifierUnsupportedDigest(t *testing.T) {
 		})
 	}
 }
+
+func TestDescriptorVerifier(t *testing.T) {
+	p := []byte("hello")
+	d := FromBytes(p)
+
+	for _, testcase := range []struct {
+		Name     string
+		Content  []byte
+		Expected *MismatchError
+	}{
+		{
+			Name:    "Match",
+			Content: p,
+		},
+		{
+			Name:    "Short",
+			Content: p[:4],
+			Expected: &MismatchError{
+				ExpectedDigest: d, ExpectedSize: 5,
+				ActualDigest: FromBytes(p[:4]), ActualSize: 4,
+			},
+		},
+		{
+			Name:    "Mismatch",
+			Content: []byte("world"),
+			Expected: &MismatchError{
+				ExpectedDigest: d, ExpectedSize: 5,
+				ActualDigest: FromString("world"), ActualSize: 5,
+			},
+		},
+	} {
+		t.Run(testcase.Name, func(t *testing.T) {
+			v := NewDescriptorVerifier(d, int64(len(p)))
+			if _, err := v.Write(testcase.Content); err != nil {
+				t.Fatal(err)
+			}
+
+			err := v.Verify()
+			if testcase.Expected == nil {
+				if err != nil || !v.Verified() {
+					t.Fatalf("expected content to be verified: %v", err)
+				}
+				return
+			}
+
+			var mismatch *MismatchError
+			if !errors.As(err, &mismatch) || *mismatch != *testcase.Expected {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+			if v.Verified() {
+				t.Fatal("expected content not to be verified")
+			}
+		})
+	}
+}
+
+func TestDescriptorVerifierExceeded(t *testing.T) {
+	d := FromString("hello")
+	v := NewDescriptorVerifier(d, 5)
+
+	// the verifier stops consuming the endless reader once the size is exceeded
+	n, err := io.CopyBuffer(v, &endlessReader{}, make([]byte, 4))
+	if n != 4 || !errors.Is(err, ErrSizeMismatch) {
+		t.Fatalf("unexpected copy: %d %v", n, err)
+	}
+	if err.Error() != "content size mismatch: expected 5 bytes, got at least 8" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if verr := v.Verify(); verr != err || v.Verified() {
+		t.Fatalf("expected error to be retained: %v", verr)
+	}
+	if _, err := v.Write([]byte("x")); !errors.Is(err, ErrSizeMismatch) {
+		t.Fatalf("unexpected error writing after failure: %v", err)
+	}
+}
+
+func TestMismatchErrorIs(t *testing.T) {
+	err := error(&MismatchError{ExpectedDigest: "sha256:a", ExpectedSize: 1, ActualDigest: "sha256:b", ActualSize: 1})
+	if !errors.Is(err, ErrDigestMismatch) || errors.Is(err, ErrSizeMismatch) {
+		t.Fatalf("unexpected matches for %v", err)
+	}
+	if err.Error() != "content digest mismatch: expected sha256:a, got sha256:b" {
+		t.Fatalf("unexpected message: %v", err)
+	}
+}
+
+func TestMultiVerifier(t *testing.T) {
+	p := []byte("hello")
+	sha256Digest, sha512Digest := FromBytes(p), SHA512.FromBytes(p)
+	other := FromString("world")
+
+	for _, testcase := range []struct {
+		Name    string
+		Digests []Digest
+		Matched []Digest
+		Any     bool
+		All     bool
+	}{
+		{
+			Name:    "AllMatch",
+			Digests: []Digest{sha256Digest, sha512Digest, sha256Digest},
+			Matched: []Digest{sha256Digest, sha512Digest},
+			Any:     true,
+			All:     true,
+		},
+		{
+			Name:    "SomeMatch",
+			Digests: []Digest{other, sha512Digest},
+			Matched: []Digest{sha512Digest},
+			Any:     true,
+		},
+		{
+			Name:    "NoneMatch",
+			Digests: []Digest{other},
+		},
+		{
+			Name: "Empty",
+		},
+	} {
+		t.Run(testcase.Name, func(t *testing.T) {
+			anyVerifier, allVerifier := NewAnyVerifier(testcase.Digests...), NewAllVerifier(testcase.Digests...)
+			for _, v := range []MultiVerifier{anyVerifier, allVerifier} {
+				if _, err := v.Write(p); err != nil {
+					t.Fatal(err)
+				}
+				if matched := v.Matched(); !reflect.DeepEqual(matched, testcase.Matched) {
+					t.Fatalf("unexpected matched digests: %v != %v", matched, testcase.Matched)
+				}
+			}
+			if anyVerifier.Verified() != testcase.Any {
+				t.Fatalf("unexpected any-of verification: %v", !testcase.Any)
+			}
+			if allVerifier.Verified() != testcase.All {
+				t.Fatalf("unexpected all-of verification: %v", !testcase.All)
+			}
+		})
+	}
+}
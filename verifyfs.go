@@ -0,0 +1,185 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"sort"
+)
+
+// ErrNotInManifest returned when opening a file missing from the manifest of
+// a file system returned by VerifyFS.
+var ErrNotInManifest = errors.New("file not in manifest")
+
+// Manifest maps the paths of files in a file system, in the form accepted by
+// fs.ValidPath, to their expected digests.
+type Manifest map[string]Digest
+
+// VerifyFS returns a file system serving the files of fsys that are listed in
+// the manifest. Reading a file verifies its content against the manifest, and
+// the final read returns an error matching ErrDigestMismatch instead of
+// io.EOF if the content does not match. Opening a regular file missing from
+// the manifest fails with ErrNotInManifest. Directories are served as is, even
+// if listed in the manifest, so listings may include such files.
+//
+// Files only implement fs.File, so that their content cannot be read around
+// the verification, for example with io.Seeker or io.ReaderAt.
+func VerifyFS(fsys fs.FS, manifest Manifest) fs.FS {
+	return &verifyingFS{fsys: fsys, manifest: manifest}
+}
+
+type verifyingFS struct {
+	fsys     fs.FS
+	manifest Manifest
+}
+
+func (vfs *verifyingFS) Open(name string) (fs.File, error) {
+	f, err := vfs.fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if fi.IsDir() {
+		return f, nil
+	}
+
+	d, ok := vfs.manifest[name]
+	if !ok {
+		if fi.Mode().IsRegular() {
+			f.Close()
+			return nil, &fs.PathError{Op: "open", Path: name, Err: ErrNotInManifest}
+		}
+		return f, nil
+	}
+
+	if err := d.Validate(); err != nil {
+		f.Close()
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	return &verifyingFile{
+		name:   name,
+		file:   f,
+		reader: newVerifyingReader(f, d),
+	}, nil
+}
+
+// Stat implements fs.StatFS. Stat only reads metadata, so it serves files
+// missing from the manifest as well.
+func (vfs *verifyingFS) Stat(name string) (fs.FileInfo, error) {
+	return fs.Stat(vfs.fsys, name)
+}
+
+type verifyingFile struct {
+	name   string
+	file   fs.File
+	reader *verifyingReader
+}
+
+func (vf *verifyingFile) Stat() (fs.FileInfo, error) {
+	return vf.file.Stat()
+}
+
+func (vf *verifyingFile) Read(p []byte) (int, error) {
+	n, err := vf.reader.Read(p)
+	if vf.reader.eof && err != nil && err != io.EOF {
+		err = &fs.PathError{Op: "read", Path: vf.name, Err: err}
+	}
+	return n, err
+}
+
+func (vf *verifyingFile) Close() error {
+	return vf.file.Close()
+}
+
+// AuditReport is the result of AuditFS.
+type AuditReport struct {
+	// Missing lists the paths in the manifest without a regular file.
+	Missing []string
+
+	// Extra lists the paths of regular files not in the manifest.
+	Extra []string
+
+	// Mismatched lists the paths of files whose content does not match the
+	// manifest.
+	Mismatched []string
+}
+
+// OK reports whether the file system matches the manifest.
+func (r *AuditReport) OK() bool {
+	return len(r.Missing) == 0 && len(r.Extra) == 0 && len(r.Mismatched) == 0
+}
+
+// AuditFS walks fsys with fs.WalkDir, verifying every regular file against
+// the manifest, and reports the differences. All paths are sorted. An error
+// is returned if fsys cannot be walked or a file cannot be read.
+func AuditFS(fsys fs.FS, manifest Manifest) (*AuditReport, error) {
+	var (
+		report AuditReport
+		seen   = map[string]bool{}
+	)
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		expected, ok := manifest[p]
+		if !ok {
+			report.Extra = append(report.Extra, p)
+			return nil
+		}
+		seen[p] = true
+		if err := expected.Validate(); err != nil {
+			return &fs.PathError{Op: "audit", Path: p, Err: err}
+		}
+
+		f, err := fsys.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		v := expected.Verifier()
+		if _, err := io.Copy(v, f); err != nil {
+			return err
+		}
+		if !v.Verified() {
+			report.Mismatched = append(report.Mismatched, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for p := range manifest {
+		if !seen[p] {
+			report.Missing = append(report.Missing, p)
+		}
+	}
+	sort.Strings(report.Missing)
+	sort.Strings(report.Extra)
+	sort.Strings(report.Mismatched)
+	return &report, nil
+}
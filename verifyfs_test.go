@@ -0,0 +1,159 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestVerifyFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.txt":       {Data: []byte("a")},
+		"dir/b.txt":   {Data: []byte("b")},
+		"dir/bad.txt": {Data: []byte("tampered")},
+		"extra.txt":   {Data: []byte("extra")},
+	}
+	manifest := Manifest{
+		"a.txt":       FromString("a"),
+		"dir/b.txt":   FromString("b"),
+		"dir/bad.txt": FromString("original"),
+		"dir":         FromString("not a file"),
+	}
+	vfs := VerifyFS(fsys, manifest)
+
+	for _, testcase := range []struct {
+		Path    string
+		Content string
+		Err     error
+	}{
+		{Path: "a.txt", Content: "a"},
+		{Path: "dir/b.txt", Content: "b"},
+		{Path: "dir/bad.txt", Content: "tampered", Err: ErrDigestMismatch},
+		{Path: "extra.txt", Err: ErrNotInManifest},
+		{Path: "missing.txt", Err: fs.ErrNotExist},
+	} {
+		p, err := fs.ReadFile(vfs, testcase.Path)
+		if !errors.Is(err, testcase.Err) {
+			t.Fatalf("unexpected error reading %q: %v", testcase.Path, err)
+		}
+		var pathErr *fs.PathError
+		if err != nil && (!errors.As(err, &pathErr) || pathErr.Path != testcase.Path) {
+			t.Fatalf("expected path error for %q: %v", testcase.Path, err)
+		}
+		if err == nil && string(p) != testcase.Content {
+			t.Fatalf("unexpected content of %q: %q", testcase.Path, p)
+		}
+	}
+
+	// malformed manifest entries are reported as errors
+	for _, testcase := range []struct {
+		Digest Digest
+		Err    error
+	}{
+		{Digest: "bogus", Err: ErrDigestInvalidFormat},
+		{Digest: "bean:0123", Err: ErrDigestUnsupported},
+	} {
+		badFS := VerifyFS(fsys, Manifest{"a.txt": testcase.Digest})
+		var pathErr *fs.PathError
+		if _, err := badFS.Open("a.txt"); !errors.Is(err, testcase.Err) || !errors.As(err, &pathErr) {
+			t.Fatalf("unexpected error for %q: %v", testcase.Digest, err)
+		}
+	}
+
+	// the files are not seekable, which would bypass the verification
+	f, err := vfs.Open("a.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, ok := f.(io.Seeker); ok {
+		t.Fatal("expected file not to implement io.Seeker")
+	}
+
+	// directories and metadata are served for all files
+	entries, err := fs.ReadDir(vfs, ".")
+	if err != nil || len(entries) != 3 {
+		t.Fatalf("unexpected directory entries: %v %v", entries, err)
+	}
+	if fi, err := fs.Stat(vfs, "extra.txt"); err != nil || fi.Size() != 5 {
+		t.Fatalf("unexpected stat: %v %v", fi, err)
+	}
+
+	// directories listed in the manifest are served as is as well
+	var walked []string
+	if err := fs.WalkDir(vfs, ".", func(p string, d fs.DirEntry, err error) error {
+		walked = append(walked, p)
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{".", "a.txt", "dir", "dir/b.txt", "dir/bad.txt", "extra.txt"}; !reflect.DeepEqual(walked, expected) {
+		t.Fatalf("unexpected walk: %v", walked)
+	}
+}
+
+func TestAuditFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.txt":       {Data: []byte("a")},
+		"dir/bad.txt": {Data: []byte("tampered")},
+		"dir/x.txt":   {Data: []byte("x")},
+		"dir/y.txt":   {Data: []byte("y")},
+		"dir.bad":     {Data: []byte("tampered")},
+		"dir.txt":     {Data: []byte("extra")},
+		"extra.txt":   {Data: []byte("extra")},
+	}
+	manifest := Manifest{
+		"a.txt":       FromString("a"),
+		"dir/bad.txt": FromString("original"),
+		"dir.bad":     FromString("original"),
+		"dir/x.txt":   SHA512.FromString("x"),
+		"gone.txt":    FromString("gone"),
+		"dir/gone":    FromString("gone"),
+	}
+
+	report, err := AuditFS(fsys, manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &AuditReport{
+		Missing:    []string{"dir/gone", "gone.txt"},
+		Extra:      []string{"dir.txt", "dir/y.txt", "extra.txt"},
+		Mismatched: []string{"dir.bad", "dir/bad.txt"},
+	}
+	if !reflect.DeepEqual(report, expected) || report.OK() {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	delete(fsys, "extra.txt")
+	delete(fsys, "dir.txt")
+	delete(fsys, "dir/y.txt")
+	fsys["dir/bad.txt"] = &fstest.MapFile{Data: []byte("original")}
+	fsys["dir.bad"] = &fstest.MapFile{Data: []byte("original")}
+	delete(manifest, "gone.txt")
+	delete(manifest, "dir/gone")
+	if report, err := AuditFS(fsys, manifest); err != nil || !report.OK() {
+		t.Fatalf("unexpected report: %+v %v", report, err)
+	}
+
+	manifest["a.txt"] = "bean:0123"
+	if _, err := AuditFS(fsys, manifest); !errors.Is(err, ErrDigestUnsupported) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
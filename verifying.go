@@ -0,0 +1,109 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"hash"
+	"io"
+)
+
+// VerifyReader returns a reader that reads from r and verifies the content
+// against d. At the end of r, it returns a *MismatchError instead of io.EOF
+// if the content does not match, so that callers reading until io.EOF, such
+// as io.Copy, fail without an extra verification step. Like Digest.Verifier,
+// it panics if the algorithm of d is not available.
+func VerifyReader(r io.Reader, d Digest) io.Reader {
+	return newVerifyingReader(r, d)
+}
+
+// VerifyReadCloser is like VerifyReader for an io.ReadCloser. Close closes rc
+// and returns a *MismatchError if the content read does not match, so that
+// content cut short before the end is reported as well.
+func VerifyReadCloser(rc io.ReadCloser, d Digest) io.ReadCloser {
+	return &verifyingReadCloser{
+		verifyingReader: newVerifyingReader(rc, d),
+		closer:          rc,
+	}
+}
+
+type verifyingReader struct {
+	r      io.Reader
+	digest Digest
+	hash   hash.Hash
+	n      int64
+	eof    bool
+	err    error // result of the verification, once eof is set
+}
+
+func newVerifyingReader(r io.Reader, d Digest) *verifyingReader {
+	return &verifyingReader{
+		r:      r,
+		digest: d,
+		hash:   d.Algorithm().Hash(),
+	}
+}
+
+func (vr *verifyingReader) Read(p []byte) (int, error) {
+	if vr.eof {
+		if vr.err != nil {
+			return 0, vr.err
+		}
+		return 0, io.EOF
+	}
+
+	n, err := vr.r.Read(p)
+	vr.hash.Write(p[:n])
+	vr.n += int64(n)
+	if err == io.EOF {
+		vr.eof = true
+		vr.err = vr.verify()
+		if vr.err != nil {
+			return n, vr.err
+		}
+	}
+	return n, err
+}
+
+// verify returns a *MismatchError if the content read so far does not match.
+func (vr *verifyingReader) verify() error {
+	actual := NewDigest(vr.digest.Algorithm(), vr.hash)
+	if actual != vr.digest {
+		return &MismatchError{
+			ExpectedDigest: vr.digest,
+			ExpectedSize:   vr.n,
+			ActualDigest:   actual,
+			ActualSize:     vr.n,
+		}
+	}
+	return nil
+}
+
+type verifyingReadCloser struct {
+	*verifyingReader
+	closer io.Closer
+}
+
+func (vrc *verifyingReadCloser) Close() error {
+	err := vrc.closer.Close()
+
+	verr := vrc.err
+	if !vrc.eof {
+		verr = vrc.verify()
+	}
+	if verr != nil {
+		return verr
+	}
+	return err
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 OCI Contributors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digest
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+type closeRecorder struct {
+	io.Reader
+	closed bool
+	err    error
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return c.err
+}
+
+func TestVerifyReader(t *testing.T) {
+	d := FromString("hello, world")
+
+	for _, testcase := range []struct {
+		Name    string
+		Content string
+		Err     error
+	}{
+		{Name: "Match", Content: "hello, world"},
+		{Name: "Mismatch", Content: "hello, there", Err: ErrDigestMismatch},
+		{Name: "Short", Content: "hello", Err: ErrDigestMismatch},
+	} {
+		t.Run(testcase.Name, func(t *testing.T) {
+			var buf bytes.Buffer
+			n, err := io.Copy(&buf, VerifyReader(strings.NewReader(testcase.Content), d))
+			if !errors.Is(err, testcase.Err) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if n != int64(len(testcase.Content)) || buf.String() != testcase.Content {
+				t.Fatalf("unexpected content: %q", buf.String())
+			}
+
+			var mismatch *MismatchError
+			if errors.As(err, &mismatch) && (mismatch.ActualDigest != FromString(testcase.Content) || errors.Is(err, ErrSizeMismatch)) {
+				t.Fatalf("unexpected mismatch: %#v", mismatch)
+			}
+		})
+	}
+}
+
+func TestVerifyReadCloser(t *testing.T) {
+	d := FromString("hello, world")
+
+	t.Run("Match", func(t *testing.T) {
+		rc := &closeRecorder{Reader: strings.NewReader("hello, world")}
+		vrc := VerifyReadCloser(rc, d)
+		if _, err := io.Copy(io.Discard, vrc); err != nil {
+			t.Fatal(err)
+		}
+		if err := vrc.Close(); err != nil || !rc.closed {
+			t.Fatalf("unexpected close: %v %v", err, rc.closed)
+		}
+	})
+
+	t.Run("CutShort", func(t *testing.T) {
+		rc := &closeRecorder{Reader: strings.NewReader("hello, world")}
+		vrc := VerifyReadCloser(rc, d)
+		if _, err := io.CopyN(io.Discard, vrc, 5); err != nil {
+			t.Fatal(err)
+		}
+		if err := vrc.Close(); !errors.Is(err, ErrDigestMismatch) || !rc.closed {
+			t.Fatalf("unexpected close: %v %v", err, rc.closed)
+		}
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		rc := &closeRecorder{Reader: strings.NewReader("hello, there")}
+		vrc := VerifyReadCloser(rc, d)
+		if _, err := io.Copy(io.Discard, vrc); !errors.Is(err, ErrDigestMismatch) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := vrc.Close(); !errors.Is(err, ErrDigestMismatch) {
+			t.Fatalf("unexpected close: %v", err)
+		}
+	})
+
+	t.Run("CloseError", func(t *testing.T) {
+		closeErr := errors.New("close failed")
+		rc := &closeRecorder{Reader: strings.NewReader("hello, world"), err: closeErr}
+		vrc := VerifyReadCloser(rc, d)
+		if _, err := io.Copy(io.Discard, vrc); err != nil {
+			t.Fatal(err)
+		}
+		if err := vrc.Close(); err != closeErr {
+			t.Fatalf("unexpected close: %v", err)
+		}
+	})
+}